@@ -0,0 +1,378 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cloudogu/gomarkdoc"
+	"github.com/cloudogu/gomarkdoc/logger"
+)
+
+// cacheVersion is incorporated into every cache key. Bump it whenever the
+// layout of the cache or the computation of its keys changes.
+const cacheVersion = "1"
+
+type (
+	// outputCache tracks the inputs that were used to generate each output
+	// file so that files whose inputs have not changed can be skipped
+	// entirely on subsequent runs.
+	outputCache struct {
+		dir  string
+		log  logger.Logger
+		keys map[string]string
+	}
+
+	// cacheEntry is the on-disk representation of the cache state for a
+	// single output file.
+	cacheEntry struct {
+		Key        string `json:"key"`
+		OutputHash string `json:"outputHash"`
+	}
+)
+
+// resolveCache creates the output cache for the provided options. If caching
+// is disabled or not applicable to the options, nil is returned.
+func resolveCache(opts commandOptions) (*outputCache, error) {
+	// The cache only pays off when writing files. Check mode and output to
+	// stdout always run the full generation.
+	if opts.noCache || opts.check || opts.output == "" {
+		return nil, nil
+	}
+
+	dir := opts.cacheDir
+	if dir == "" {
+		userCache, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: unable to determine cache directory: %w", err)
+		}
+
+		dir = filepath.Join(userCache, "gomarkdoc")
+	}
+
+	return &outputCache{
+		dir:  dir,
+		log:  logger.New(getLogLevel(opts.verbosity), logger.WithField("cache", dir)),
+		keys: make(map[string]string),
+	}, nil
+}
+
+// prepare computes the cache key for every output file referenced by the
+// provided specs and marks the specs whose output file is up to date as
+// cached. Cached specs don't need to be loaded or rendered.
+func (c *outputCache) prepare(specs []*PackageSpec, opts commandOptions) error {
+	settings, err := hashSettings(opts)
+	if err != nil {
+		return err
+	}
+
+	var fileNames []string
+	fileSpecs := make(map[string][]*PackageSpec)
+	for _, spec := range specs {
+		if _, ok := fileSpecs[spec.outputFile]; !ok {
+			fileNames = append(fileNames, spec.outputFile)
+		}
+
+		fileSpecs[spec.outputFile] = append(fileSpecs[spec.outputFile], spec)
+	}
+
+	for _, fileName := range fileNames {
+		h := sha256.New()
+		fmt.Fprintf(h, "%s\x00%s\x00", cacheVersion, settings)
+
+		for _, spec := range fileSpecs[fileName] {
+			if err := hashSpec(h, spec); err != nil {
+				return err
+			}
+		}
+
+		if opts.embed {
+			if err := hashEmbedRegions(h, fileName, opts); err != nil {
+				return err
+			}
+		}
+
+		key := hex.EncodeToString(h.Sum(nil))
+		c.keys[fileName] = key
+
+		if !c.upToDate(fileName, key) {
+			continue
+		}
+
+		c.log.Debugf("skipping %s because its inputs have not changed", fileName)
+		for _, spec := range fileSpecs[fileName] {
+			spec.cached = true
+		}
+	}
+
+	return nil
+}
+
+// prepareIndex computes the cache key for the index output, which depends on
+// every package spec and the output file it is linked to. If the index is not
+// up to date, the packages of cached specs are marked to be loaded for the
+// index, without rendering their output again. The returned value reports
+// whether the index is up to date.
+func (c *outputCache) prepareIndex(specs []*PackageSpec, opts commandOptions) (bool, error) {
+	settings, err := hashSettings(opts)
+	if err != nil {
+		return false, err
+	}
+
+	fileName := filepath.Clean(opts.indexOutput)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00index=%s\x00", cacheVersion, settings, fileName)
+	for _, spec := range specs {
+		if err := hashSpec(h, spec); err != nil {
+			return false, err
+		}
+
+		fmt.Fprintf(h, "output=%s\x00", spec.outputFile)
+	}
+
+	key := hex.EncodeToString(h.Sum(nil))
+	c.keys[fileName] = key
+
+	if c.upToDate(fileName, key) {
+		c.log.Debugf("skipping %s because its inputs have not changed", fileName)
+		return true, nil
+	}
+
+	for _, spec := range specs {
+		spec.loadForIndex = spec.cached
+	}
+
+	return false, nil
+}
+
+// upToDate reports whether the output file was generated from the inputs
+// identified by key and has not been modified since.
+func (c *outputCache) upToDate(fileName, key string) bool {
+	b, err := ioutil.ReadFile(c.entryPath(fileName))
+	if err != nil {
+		return false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil {
+		c.log.Debugf("ignoring invalid cache entry for %s: %s", fileName, err)
+		return false
+	}
+
+	if entry.Key != key {
+		return false
+	}
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return false
+	}
+
+	return hex.EncodeToString(h.Sum(nil)) == entry.OutputHash
+}
+
+// store records that the output file now holds the provided text, generated
+// from the inputs that were identified during prepare. Failures to write the
+// cache are logged but otherwise ignored, since they only affect performance.
+func (c *outputCache) store(fileName, text string) {
+	key, ok := c.keys[fileName]
+	if !ok {
+		return
+	}
+
+	sum := sha256.Sum256([]byte(text))
+	b, err := json.Marshal(cacheEntry{
+		Key:        key,
+		OutputHash: hex.EncodeToString(sum[:]),
+	})
+	if err != nil {
+		c.log.Warnf("unable to encode cache entry for %s: %s", fileName, err)
+		return
+	}
+
+	if err := writeFile(c.entryPath(fileName), string(b)); err != nil {
+		c.log.Warnf("unable to write cache entry for %s: %s", fileName, err)
+	}
+}
+
+// entryPath provides the location of the cache entry for an output file. The
+// entry is identified by the absolute path of the file.
+func (c *outputCache) entryPath(fileName string) string {
+	abs, err := filepath.Abs(fileName)
+	if err != nil {
+		abs = fileName
+	}
+
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// hashSettings computes a digest of all options that affect the rendering of
+// an output file independently of the packages documented in it.
+func hashSettings(opts commandOptions) (string, error) {
+	h := sha256.New()
+
+	writeField := func(name string, values ...string) {
+		fmt.Fprintf(h, "%s=%s\x00", name, strings.Join(values, "\x1f"))
+	}
+
+	writeField("version", getVersion())
+	writeField("format", opts.format)
+	writeField("calloutStyle", opts.calloutStyle)
+	writeField("profile", opts.profile)
+	writeField("locale", opts.locale)
+	writeField("order", opts.order)
+	writeField("groupOrder", opts.groupOrder...)
+	writeField("embed", fmt.Sprint(opts.embed))
+	writeField("includeUnexported", fmt.Sprint(opts.includeUnexported))
+	writeField("excludeDeprecated", fmt.Sprint(opts.excludeDeprecated))
+	writeField("includeFiles", opts.includeFiles...)
+	writeField("tags", opts.tags...)
+	writeField(
+		"repository",
+		opts.repository.Remote,
+		opts.repository.DefaultBranch,
+		opts.repository.PathFromRoot,
+		opts.repository.Ref,
+		string(opts.repository.RefKind),
+		opts.repositoryPin,
+		strings.Join(opts.repositoryRemotes, ","),
+	)
+
+	header, err := resolveHeader(opts)
+	if err != nil {
+		return "", err
+	}
+	writeField("header", header)
+
+	footer, err := resolveFooter(opts)
+	if err != nil {
+		return "", err
+	}
+	writeField("footer", footer)
+
+	for _, name := range sortedKeys(opts.templateOverrides) {
+		writeField("template."+name, opts.templateOverrides[name])
+	}
+
+	for _, name := range sortedKeys(opts.templateFileOverrides) {
+		b, err := ioutil.ReadFile(opts.templateFileOverrides[name])
+		if err != nil {
+			return "", fmt.Errorf("gomarkdoc: couldn't resolve template for %s: %w", name, err)
+		}
+
+		writeField("templateFile."+name, string(b))
+	}
+
+	if opts.messagesFile != "" {
+		b, err := ioutil.ReadFile(opts.messagesFile)
+		if err != nil {
+			return "", fmt.Errorf("gomarkdoc: couldn't read messages file: %w", err)
+		}
+
+		writeField("messagesFile", string(b))
+	}
+
+	if opts.templateDir != "" {
+		tmpls, err := gomarkdoc.ReadTemplateDir(opts.templateDir)
+		if err != nil {
+			return "", err
+		}
+
+		for _, name := range sortedKeys(tmpls) {
+			writeField("templateDir."+name, tmpls[name])
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashSpec writes the identity of a package spec and the contents of all of
+// the go files in its directory to the provided hash. Test files are included
+// since they provide the examples for the package.
+func hashSpec(h hash.Hash, spec *PackageSpec) error {
+	fmt.Fprintf(h, "spec=%s\x00", spec.ImportPath)
+
+	if spec.buildPkg == nil {
+		return nil
+	}
+
+	files, err := ioutil.ReadDir(spec.buildPkg.Dir)
+	if err != nil {
+		return fmt.Errorf("gomarkdoc: error reading package dir: %w", err)
+	}
+
+	for _, f := range files {
+		if !f.Mode().IsRegular() || !strings.HasSuffix(f.Name(), ".go") {
+			continue
+		}
+
+		b, err := ioutil.ReadFile(filepath.Join(spec.buildPkg.Dir, f.Name()))
+		if err != nil {
+			return fmt.Errorf("gomarkdoc: error reading package file %s: %w", f.Name(), err)
+		}
+
+		fmt.Fprintf(h, "file=%s\x00%d\x00", f.Name(), len(b))
+		h.Write(b)
+	}
+
+	return nil
+}
+
+// hashEmbedRegions writes the packages referenced by the embed regions of the
+// output file to the provided hash. Problems with the regions are ignored here
+// since they are reported when the file is generated.
+func hashEmbedRegions(h hash.Hash, fileName string, opts commandOptions) error {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil
+	}
+
+	regions, err := findEmbedAttrs(fileName, data)
+	if err != nil {
+		return nil
+	}
+
+	for _, attrs := range regions {
+		if attrs.pkg == "" {
+			continue
+		}
+
+		spec, err := regionSpec(fileName, attrs.pkg, opts.tags)
+		if err != nil {
+			continue
+		}
+
+		if err := hashSpec(h, spec); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/matryer/is"
+
+	"github.com/cloudogu/gomarkdoc"
+	"github.com/cloudogu/gomarkdoc/lang"
+)
+
+func TestOutputCache(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	outputFile := filepath.Join(dir, "README.md")
+
+	opts := commandOptions{
+		output:   outputFile,
+		format:   "github",
+		cacheDir: filepath.Join(dir, "cache"),
+	}
+
+	newSpecs := func() []*PackageSpec {
+		specs := getSpecs(filepath.Join(wd, "../../testData/simple"))
+		specs[0].outputFile = outputFile
+		is.NoErr(resolveBuildPackages(specs, opts))
+		return specs
+	}
+
+	cache, err := resolveCache(opts)
+	is.NoErr(err)
+	is.True(cache != nil)
+
+	// Nothing has been generated yet
+	specs := newSpecs()
+	is.NoErr(cache.prepare(specs, opts))
+	is.True(!specs[0].cached)
+
+	is.NoErr(writeFile(outputFile, "content"))
+	cache.store(outputFile, "content")
+
+	// Unchanged inputs and output
+	specs = newSpecs()
+	is.NoErr(cache.prepare(specs, opts))
+	is.True(specs[0].cached)
+
+	// Changed settings
+	opts.header = "header"
+	specs = newSpecs()
+	is.NoErr(cache.prepare(specs, opts))
+	is.True(!specs[0].cached)
+	opts.header = ""
+
+	// Pinned source links
+	opts.repositoryPin = "commit"
+	specs = newSpecs()
+	is.NoErr(cache.prepare(specs, opts))
+	is.True(!specs[0].cached)
+	opts.repositoryPin = ""
+
+	// Modified output
+	is.NoErr(os.WriteFile(outputFile, []byte("modified"), 0664))
+	specs = newSpecs()
+	is.NoErr(cache.prepare(specs, opts))
+	is.True(!specs[0].cached)
+}
+
+func TestOutputCache_index(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	simpleOutput := filepath.Join(dir, "simple", "README.md")
+	nestedOutput := filepath.Join(dir, "nested", "README.md")
+
+	opts := commandOptions{
+		output:      filepath.Join(dir, "{{.ImportPath}}", "README.md"),
+		indexOutput: filepath.Join(dir, "README.md"),
+		format:      "github",
+		cacheDir:    filepath.Join(dir, "cache"),
+	}
+
+	newSpecs := func(names ...string) []*PackageSpec {
+		var specs []*PackageSpec
+		for _, name := range names {
+			spec := getSpecs(filepath.Join(wd, "../../testData", name))[0]
+			spec.outputFile = filepath.Join(dir, name, "README.md")
+			specs = append(specs, spec)
+		}
+
+		is.NoErr(resolveBuildPackages(specs, opts))
+		return specs
+	}
+
+	cache, err := resolveCache(opts)
+	is.NoErr(err)
+
+	specs := newSpecs("simple")
+	is.NoErr(cache.prepare(specs, opts))
+	indexCached, err := cache.prepareIndex(specs, opts)
+	is.NoErr(err)
+	is.True(!indexCached)
+
+	is.NoErr(writeFile(simpleOutput, "content"))
+	cache.store(simpleOutput, "content")
+	is.NoErr(writeFile(opts.indexOutput, "index"))
+	cache.store(opts.indexOutput, "index")
+
+	// Adding a package only renders the index and the new package
+	specs = newSpecs("simple", "nested")
+	is.NoErr(cache.prepare(specs, opts))
+	indexCached, err = cache.prepareIndex(specs, opts)
+	is.NoErr(err)
+	is.True(!indexCached)
+	is.True(specs[0].cached)
+	is.True(specs[0].loadForIndex)
+	is.True(!specs[1].cached)
+
+	out, err := gomarkdoc.NewRenderer()
+	is.NoErr(err)
+
+	shared := &sharedPackages{pkgs: make(map[string]*lang.Package)}
+	is.NoErr(loadPackages(specs, opts, shared))
+	is.True(specs[0].pkg != nil) // loaded for the index
+	is.NoErr(writeOutput(specs, opts, out, cache, shared))
+	is.NoErr(writeIndex(specs, opts, out, cache))
+
+	b, err := os.ReadFile(simpleOutput)
+	is.NoErr(err)
+	is.Equal(string(b), "content") // the cached output was not rendered again
+
+	_, err = os.Stat(nestedOutput)
+	is.NoErr(err)
+
+	b, err = os.ReadFile(opts.indexOutput)
+	is.NoErr(err)
+	is.True(string(b) != "index")
+
+	specs = newSpecs("simple", "nested")
+	is.NoErr(cache.prepare(specs, opts))
+	indexCached, err = cache.prepareIndex(specs, opts)
+	is.NoErr(err)
+	is.True(indexCached)
+	is.True(!specs[0].loadForIndex)
+}
+
+func TestOutputCache_indexPinnedRef(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	is.NoErr(err)
+
+	commit := func(name, content string) {
+		is.NoErr(os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		is.NoErr(os.WriteFile(filepath.Join(dir, name), []byte(content), 0664))
+
+		wt, err := repo.Worktree()
+		is.NoErr(err)
+
+		_, err = wt.Add(name)
+		is.NoErr(err)
+
+		_, err = wt.Commit("commit "+name, &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Unix(0, 0)},
+		})
+		is.NoErr(err)
+	}
+
+	commit("go.mod", "module example.com/pinned\n\ngo 1.19\n")
+	commit("pkg/pkg.go", "// Package pkg is documented.\npackage pkg\n")
+
+	outputFile := filepath.Join(dir, "pkg", "README.md")
+	opts := commandOptions{
+		output:        outputFile,
+		indexOutput:   filepath.Join(dir, "README.md"),
+		format:        "github",
+		cacheDir:      t.TempDir(),
+		repositoryPin: "commit",
+	}
+
+	// The pinned ref is resolved once per run, like runCommand does.
+	newSpecs := func() ([]*PackageSpec, commandOptions) {
+		runOpts := opts
+		specs := getSpecs(filepath.Join(dir, "pkg"))
+		specs[0].outputFile = outputFile
+		is.NoErr(resolveBuildPackages(specs, runOpts))
+		resolvePinnedRef(specs, &runOpts)
+		return specs, runOpts
+	}
+
+	cache, err := resolveCache(opts)
+	is.NoErr(err)
+
+	specs, runOpts := newSpecs()
+	head, err := repo.Head()
+	is.NoErr(err)
+	is.Equal(runOpts.repository.Ref, head.Hash().String())
+	is.Equal(runOpts.repository.RefKind, lang.CommitRef)
+
+	is.NoErr(cache.prepare(specs, runOpts))
+	_, err = cache.prepareIndex(specs, runOpts)
+	is.NoErr(err)
+
+	is.NoErr(writeFile(opts.indexOutput, "index"))
+	cache.store(opts.indexOutput, "index")
+
+	specs, runOpts = newSpecs()
+	indexCached, err := cache.prepareIndex(specs, runOpts)
+	is.NoErr(err)
+	is.True(indexCached)
+
+	// A new commit changes the source links of the index, even though the
+	// sources did not change.
+	commit("CHANGELOG.md", "changes")
+
+	specs, runOpts = newSpecs()
+	indexCached, err = cache.prepareIndex(specs, runOpts)
+	is.NoErr(err)
+	is.True(!indexCached)
+}
+
+func TestResolveCache_disabled(t *testing.T) {
+	is := is.New(t)
+
+	tests := map[string]commandOptions{
+		"noCache": {output: "README.md", noCache: true},
+		"check":   {output: "README.md", check: true},
+		"stdout":  {},
+	}
+
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			cache, err := resolveCache(opts)
+			is.NoErr(err)
+			is.True(cache == nil)
+		})
+	}
+}
+
+func TestWriteFile_unchanged(t *testing.T) {
+	is := is.New(t)
+
+	fileName := filepath.Join(t.TempDir(), "README.md")
+	is.NoErr(writeFile(fileName, "content"))
+
+	// Make the file read-only so that any attempt to rewrite it fails
+	is.NoErr(os.Chmod(fileName, 0444))
+
+	is.NoErr(writeFile(fileName, "content"))
+}
@@ -17,6 +17,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
 	"github.com/cloudogu/gomarkdoc"
@@ -40,26 +41,47 @@ type PackageSpec struct {
 	isWildcard bool
 	isLocal    bool
 	outputFile string
+	buildPkg   *build.Package
 	pkg        *lang.Package
+	cached     bool
+
+	// loadForIndex marks cached specs whose package is still needed, since
+	// the index is rendered from the packages of all specs.
+	loadForIndex bool
 }
 
 type commandOptions struct {
 	repository            lang.Repo
+	repositoryPin         string
+	repositoryRemotes     []string
 	output                string
+	indexOutput           string
 	header                string
 	headerFile            string
 	footer                string
 	footerFile            string
 	format                string
+	calloutStyle          string
 	tags                  []string
 	templateOverrides     map[string]string
 	templateFileOverrides map[string]string
+	templateDir           string
+	profile               string
+	locale                string
+	messagesFile          string
+	order                 string
+	groupOrder            []string
 	verbosity             int
 	includeUnexported     bool
+	excludeDeprecated     bool
 	check                 bool
+	verifyExamples        bool
 	embed                 bool
 	version               bool
 	includeFiles          []string
+	jobs                  int
+	noCache               bool
+	cacheDir              string
 }
 
 // Flags populated by goreleaser
@@ -67,15 +89,61 @@ var version = ""
 
 const configFilePrefix = ".gomarkdoc"
 
+// configBinding associates a key of the configuration file with the command
+// line flag providing the same option.
+type configBinding struct {
+	key  string
+	flag string
+}
+
+// configBindings lists every option that may be provided through the
+// configuration file, in the order in which they are documented.
+var configBindings = []configBinding{
+	{"includeUnexported", "include-unexported"},
+	{"excludeDeprecated", "exclude-deprecated"},
+	{"output", "output"},
+	{"indexOutput", "index-output"},
+	{"check", "check"},
+	{"verifyExamples", "verify-examples"},
+	{"embed", "embed"},
+	{"format", "format"},
+	{"calloutStyle", "callout-style"},
+	{"template", "template"},
+	{"templateFile", "template-file"},
+	{"templateDir", "template-dir"},
+	{"profile", "profile"},
+	{"locale", "locale"},
+	{"messagesFile", "messages-file"},
+	{"order", "order"},
+	{"groupOrder", "group-order"},
+	{"header", "header"},
+	{"headerFile", "header-file"},
+	{"footer", "footer"},
+	{"footerFile", "footer-file"},
+	{"tags", "tags"},
+	{"repository.url", "repository.url"},
+	{"repository.defaultBranch", "repository.default-branch"},
+	{"repository.path", "repository.path"},
+	{"repository.ref", "repository.ref"},
+	{"repository.pin", "repository.pin"},
+	{"repository.remote", "repository.remote"},
+	{"includeFiles", "include-files"},
+	{"jobs", "jobs"},
+	{"noCache", "no-cache"},
+	{"cacheDir", "cache-dir"},
+}
+
 func buildCommand() *cobra.Command {
 	var opts commandOptions
 	var configFile string
+	var targetNames []string
 
 	// cobra.OnInitialize(func() { buildConfig(configFile) })
 
 	var command = &cobra.Command{
 		Use:   "gomarkdoc [package ...]",
 		Short: "generate markdown documentation for golang code",
+		Args:  cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if opts.version {
 				printVersion()
@@ -84,44 +152,73 @@ func buildCommand() *cobra.Command {
 
 			buildConfig(configFile)
 
-			// Load configuration from viper
-			opts.includeUnexported = viper.GetBool("includeUnexported")
-			opts.output = viper.GetString("output")
-			opts.check = viper.GetBool("check")
-			opts.embed = viper.GetBool("embed")
-			opts.format = viper.GetString("format")
-			opts.templateOverrides = viper.GetStringMapString("template")
-			opts.templateFileOverrides = viper.GetStringMapString("templateFile")
-			opts.header = viper.GetString("header")
-			opts.headerFile = viper.GetString("headerFile")
-			opts.footer = viper.GetString("footer")
-			opts.footerFile = viper.GetString("footerFile")
-			opts.tags = viper.GetStringSlice("tags")
-			opts.repository.Remote = viper.GetString("repository.url")
-			opts.repository.DefaultBranch = viper.GetString("repository.defaultBranch")
-			opts.repository.PathFromRoot = viper.GetString("repository.path")
-			opts.includeFiles = viper.GetStringSlice("includeFiles")
+			loadOptions(&opts)
+
+			targets, err := loadTargets()
+			if err != nil {
+				return err
+			}
+
+			if len(targetNames) > 0 && len(args) > 0 {
+				return errTargetsWithPackages
+			}
+
+			// Targets from the configuration file are run unless packages are
+			// provided on the command line.
+			if len(targets) > 0 && len(args) == 0 {
+				selected, err := selectTargets(targets, targetNames)
+				if err != nil {
+					return err
+				}
+
+				return runTargets(selected, opts)
+			}
+
+			if len(targetNames) > 0 {
+				return errors.New("gomarkdoc: no targets are configured")
+			}
 
 			if opts.check && opts.output == "" {
 				return errors.New("gomarkdoc: check mode cannot be run without an output set")
 			}
 
+			if opts.indexOutput != "" && opts.output == "" {
+				return errors.New("gomarkdoc: index output cannot be written without an output set")
+			}
+
 			if len(args) == 0 {
 				// Default to current directory
 				args = []string{"."}
 			}
 
-			return runCommand(args, opts)
+			out, err := newRenderer(opts)
+			if err != nil {
+				return err
+			}
+
+			// Packages are shared so that embed regions referencing the same
+			// package only load it once.
+			shared := &sharedPackages{pkgs: make(map[string]*lang.Package)}
+			return runCommand(args, opts, out, shared)
 		},
 	}
 
 	flags := command.Flags()
-	flags.StringVar(
+
+	// Options shared with the subcommands are persistent.
+	persistentFlags := command.PersistentFlags()
+	persistentFlags.StringVar(
 		&configFile,
 		"config",
 		"",
 		fmt.Sprintf("File from which to load configuration (default: %s.yml)", configFilePrefix),
 	)
+	persistentFlags.StringSliceVar(
+		&targetNames,
+		"target",
+		[]string{},
+		"Name of a target from the configuration file to run. Runs all configured targets if not provided.",
+	)
 	flags.BoolVarP(
 		&opts.includeUnexported,
 		"include-unexported",
@@ -129,6 +226,12 @@ func buildCommand() *cobra.Command {
 		false,
 		"Output documentation for unexported symbols, methods and fields in addition to exported ones.",
 	)
+	flags.BoolVar(
+		&opts.excludeDeprecated,
+		"exclude-deprecated",
+		false,
+		"Omit symbols, methods and fields whose documentation contains a \"Deprecated:\" paragraph.",
+	)
 	flags.StringVarP(
 		&opts.output,
 		"output",
@@ -136,6 +239,12 @@ func buildCommand() *cobra.Command {
 		"",
 		"File or pattern specifying where to write documentation output. Defaults to printing to stdout.",
 	)
+	flags.StringVar(
+		&opts.indexOutput,
+		"index-output",
+		"",
+		"File to which an index of all documented packages is written, linking to the output of each package. --output must be specified to use this.",
+	)
 	flags.BoolVarP(
 		&opts.check,
 		"check",
@@ -143,33 +252,61 @@ func buildCommand() *cobra.Command {
 		false,
 		"Check the output to see if it matches the generated documentation. --output must be specified to use this.",
 	)
+	flags.BoolVar(
+		&opts.verifyExamples,
+		"verify-examples",
+		false,
+		"Run the examples of each package with go test and fail if an example fails to build or its output doesn't match the documented output.",
+	)
 	flags.BoolVarP(
 		&opts.embed,
 		"embed",
 		"e",
 		false,
-		"Embed documentation into existing markdown files if available, otherwise append to file.",
+		"Embed documentation into existing files if available, otherwise append to file.",
 	)
-	flags.StringVarP(
+	persistentFlags.StringVarP(
 		&opts.format,
 		"format",
 		"f",
 		"github",
 		"Format to use for writing output data. Valid options: github (default), azure-devops, plain",
 	)
-	flags.StringToStringVarP(
+	persistentFlags.StringVar(
+		&opts.calloutStyle,
+		"callout-style",
+		"native",
+		"Style of callouts such as notes and warnings. Valid options: native (default) renders them as supported by the format, aside renders HTML aside elements",
+	)
+	persistentFlags.StringToStringVarP(
 		&opts.templateOverrides,
 		"template",
 		"t",
 		map[string]string{},
 		"Custom template string to use for the provided template name instead of the default template.",
 	)
-	flags.StringToStringVar(
+	persistentFlags.StringToStringVar(
 		&opts.templateFileOverrides,
 		"template-file",
 		map[string]string{},
 		"Custom template file to use for the provided template name instead of the default template.",
 	)
+	persistentFlags.StringVar(
+		&opts.templateDir,
+		"template-dir",
+		"",
+		"Directory of .gotxt template files overriding the default templates of the same name or adding new ones.",
+	)
+	persistentFlags.StringVar(
+		&opts.profile,
+		"profile",
+		gomarkdoc.ProfileStructs,
+		fmt.Sprintf(
+			"Set of built-in templates to use. Valid options: %s (struct and interface types, default), %s (all symbols)",
+			gomarkdoc.ProfileStructs,
+			gomarkdoc.ProfileFull,
+		),
+	)
 	flags.StringVar(
 		&opts.header,
 		"header",
@@ -216,7 +353,25 @@ func buildCommand() *cobra.Command {
 		&opts.repository.DefaultBranch,
 		"repository.default-branch",
 		"",
-		"Manual override for the git repository URL used in place of automatic detection.",
+		"Manual override for the default branch of the git repository used in place of automatic detection.",
+	)
+	flags.StringVar(
+		&opts.repository.Ref,
+		"repository.ref",
+		"",
+		"Branch, tag or commit which source links point at in place of the default branch, such as the tag of a release.",
+	)
+	flags.StringSliceVar(
+		&opts.repositoryRemotes,
+		"repository.remote",
+		nil,
+		"Names of the git remotes to detect the repository URL and default branch from, in order of preference. Other remotes are used if none of them is usable. (default: origin)",
+	)
+	flags.StringVar(
+		&opts.repositoryPin,
+		"repository.pin",
+		"",
+		"Pin source links to the HEAD commit (commit) or the nearest tag (tag) instead of the default branch. Valid options: branch, commit, tag",
 	)
 	flags.StringVar(
 		&opts.repository.PathFromRoot,
@@ -230,34 +385,122 @@ func buildCommand() *cobra.Command {
 		false,
 		"Print the version.",
 	)
+	flags.StringVar(
+		&opts.locale,
+		"locale",
+		"",
+		fmt.Sprintf(
+			"Language of the text generated for the documentation, such as headings and titles. Valid options: %s (default: %s)",
+			strings.Join(lang.Locales(), ", "),
+			lang.DefaultLocale,
+		),
+	)
+	flags.StringVar(
+		&opts.messagesFile,
+		"messages-file",
+		"",
+		"JSON file mapping messages such as \"type %s\" to translations which override or extend those of the locale.",
+	)
+	flags.StringVar(
+		&opts.order,
+		"order",
+		"",
+		fmt.Sprintf(
+			"Order of types, functions and struct fields. Valid options: %s (default: alphabetical types and functions, fields in declaration order)",
+			strings.Join(lang.Orders(), ", "),
+		),
+	)
+	flags.StringSliceVar(
+		&opts.groupOrder,
+		"group-order",
+		[]string{},
+		"Groups set with the //gomarkdoc:group directive which are listed first, in the provided order.",
+	)
 	flags.StringSliceVar(
 		&opts.includeFiles,
 		"include-files",
 		[]string{},
 		"Set of files which should be used for generation. Default: All files from package",
 	)
+	flags.IntVarP(
+		&opts.jobs,
+		"jobs",
+		"j",
+		0,
+		"Number of packages to load and render concurrently. Defaults to the number of available CPUs.",
+	)
+	flags.BoolVar(
+		&opts.noCache,
+		"no-cache",
+		false,
+		"Always regenerate all output files instead of skipping files whose inputs have not changed.",
+	)
+	flags.StringVar(
+		&opts.cacheDir,
+		"cache-dir",
+		"",
+		"Directory in which to store the generation cache. Defaults to a gomarkdoc folder in the user cache directory.",
+	)
 
 	// We ignore the errors here because they only happen if the specified flag doesn't exist
-	_ = viper.BindPFlag("includeUnexported", flags.Lookup("include-unexported"))
-	_ = viper.BindPFlag("output", flags.Lookup("output"))
-	_ = viper.BindPFlag("check", flags.Lookup("check"))
-	_ = viper.BindPFlag("embed", flags.Lookup("embed"))
-	_ = viper.BindPFlag("format", flags.Lookup("format"))
-	_ = viper.BindPFlag("template", flags.Lookup("template"))
-	_ = viper.BindPFlag("templateFile", flags.Lookup("template-file"))
-	_ = viper.BindPFlag("header", flags.Lookup("header"))
-	_ = viper.BindPFlag("headerFile", flags.Lookup("header-file"))
-	_ = viper.BindPFlag("footer", flags.Lookup("footer"))
-	_ = viper.BindPFlag("footerFile", flags.Lookup("footer-file"))
-	_ = viper.BindPFlag("tags", flags.Lookup("tags"))
-	_ = viper.BindPFlag("repository.url", flags.Lookup("repository.url"))
-	_ = viper.BindPFlag("repository.defaultBranch", flags.Lookup("repository.default-branch"))
-	_ = viper.BindPFlag("repository.path", flags.Lookup("repository.path"))
-	_ = viper.BindPFlag("includeFiles", flags.Lookup("include-files"))
+	for _, b := range configBindings {
+		_ = viper.BindPFlag(b.key, lookupFlag(command, b.flag))
+	}
+
+	command.AddCommand(buildInitCommand(command))
+	command.AddCommand(buildTemplatesCommand(&configFile, &targetNames))
+	command.AddCommand(buildDiffCommand(&configFile))
+	command.AddCommand(buildLintCommand(&configFile))
 
 	return command
 }
 
+// loadOptions loads the options bound in configBindings from viper, which
+// merges the command line flags with the configuration file.
+func loadOptions(opts *commandOptions) {
+	opts.includeUnexported = viper.GetBool("includeUnexported")
+	opts.excludeDeprecated = viper.GetBool("excludeDeprecated")
+	opts.output = viper.GetString("output")
+	opts.indexOutput = viper.GetString("indexOutput")
+	opts.check = viper.GetBool("check")
+	opts.verifyExamples = viper.GetBool("verifyExamples")
+	opts.embed = viper.GetBool("embed")
+	opts.format = viper.GetString("format")
+	opts.calloutStyle = viper.GetString("calloutStyle")
+	opts.templateOverrides = viper.GetStringMapString("template")
+	opts.templateFileOverrides = viper.GetStringMapString("templateFile")
+	opts.templateDir = viper.GetString("templateDir")
+	opts.profile = viper.GetString("profile")
+	opts.locale = viper.GetString("locale")
+	opts.messagesFile = viper.GetString("messagesFile")
+	opts.order = viper.GetString("order")
+	opts.groupOrder = viper.GetStringSlice("groupOrder")
+	opts.header = viper.GetString("header")
+	opts.headerFile = viper.GetString("headerFile")
+	opts.footer = viper.GetString("footer")
+	opts.footerFile = viper.GetString("footerFile")
+	opts.tags = viper.GetStringSlice("tags")
+	opts.repository.Remote = viper.GetString("repository.url")
+	opts.repository.DefaultBranch = viper.GetString("repository.defaultBranch")
+	opts.repository.PathFromRoot = viper.GetString("repository.path")
+	opts.repository.Ref = viper.GetString("repository.ref")
+	opts.repositoryPin = viper.GetString("repository.pin")
+	opts.repositoryRemotes = viper.GetStringSlice("repository.remote")
+	opts.includeFiles = viper.GetStringSlice("includeFiles")
+	opts.jobs = viper.GetInt("jobs")
+	opts.noCache = viper.GetBool("noCache")
+	opts.cacheDir = viper.GetString("cacheDir")
+}
+
+// lookupFlag finds a local or persistent flag of the command.
+func lookupFlag(command *cobra.Command, name string) *pflag.Flag {
+	if f := command.Flags().Lookup(name); f != nil {
+		return f
+	}
+
+	return command.PersistentFlags().Lookup(name)
+}
+
 func defaultTags() []string {
 	f, ok := os.LookupEnv("GOFLAGS")
 	if !ok {
@@ -296,7 +539,7 @@ func buildConfig(configFile string) {
 	}
 }
 
-func runCommand(paths []string, opts commandOptions) error {
+func runCommand(paths []string, opts commandOptions, out *gomarkdoc.Renderer, shared *sharedPackages) error {
 	outputTmpl, err := template.New("output").Parse(opts.output)
 	if err != nil {
 		return fmt.Errorf("gomarkdoc: invalid output template: %w", err)
@@ -308,11 +551,57 @@ func runCommand(paths []string, opts commandOptions) error {
 		return err
 	}
 
-	if err := loadPackages(specs, opts); err != nil {
+	if err := resolveBuildPackages(specs, opts); err != nil {
+		return err
+	}
+
+	resolvePinnedRef(specs, &opts)
+
+	// Examples are verified before any output is written, so stale examples
+	// don't end up in the documentation.
+	if opts.verifyExamples {
+		if err := verifyExamples(specs, opts); err != nil {
+			return err
+		}
+	}
+
+	cache, err := resolveCache(opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.indexOutput != "" {
+		if err := checkIndexOutput(specs, opts); err != nil {
+			return err
+		}
+	}
+
+	indexCached := false
+	if cache != nil {
+		if err := cache.prepare(specs, opts); err != nil {
+			return err
+		}
+
+		if opts.indexOutput != "" {
+			if indexCached, err = cache.prepareIndex(specs, opts); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := loadPackages(specs, opts, shared); err != nil {
+		return err
+	}
+
+	if err := writeOutput(specs, opts, out, cache, shared); err != nil {
 		return err
 	}
 
-	return writeOutput(specs, opts)
+	if opts.indexOutput == "" || indexCached {
+		return nil
+	}
+
+	return writeIndex(specs, opts, out, cache)
 }
 
 func resolveOutput(specs []*PackageSpec, outputTmpl *template.Template) error {
@@ -338,6 +627,25 @@ func resolveOutput(specs []*PackageSpec, outputTmpl *template.Template) error {
 func resolveOverrides(opts commandOptions) ([]gomarkdoc.RendererOption, error) {
 	var overrides []gomarkdoc.RendererOption
 
+	if opts.profile != "" {
+		overrides = append(overrides, gomarkdoc.WithProfile(opts.profile))
+	}
+
+	catalog, err := loadCatalog(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if catalog != nil {
+		overrides = append(overrides, gomarkdoc.WithCatalog(catalog))
+	}
+
+	// Templates from the template directory have the lowest precedence, so
+	// they are applied first and may be replaced by the overrides below.
+	if opts.templateDir != "" {
+		overrides = append(overrides, gomarkdoc.WithTemplateDir(opts.templateDir))
+	}
+
 	// Content overrides take precedence over file overrides
 	for name, s := range opts.templateOverrides {
 		overrides = append(overrides, gomarkdoc.WithTemplateOverride(name, s))
@@ -370,11 +678,39 @@ func resolveOverrides(opts commandOptions) ([]gomarkdoc.RendererOption, error) {
 		return nil, fmt.Errorf("gomarkdoc: invalid format: %s", opts.format)
 	}
 
+	switch opts.calloutStyle {
+	case "", "native":
+	case "aside":
+		f = &format.AsideCallouts{Format: f}
+	default:
+		return nil, fmt.Errorf("gomarkdoc: invalid callout style: %s", opts.calloutStyle)
+	}
+
 	overrides = append(overrides, gomarkdoc.WithFormat(f))
 
 	return overrides, nil
 }
 
+// loadCatalog creates the catalog translating the generated text for the
+// configured locale and messages file. If neither is configured, no catalog is
+// needed and nil is returned.
+func loadCatalog(opts commandOptions) (*lang.Catalog, error) {
+	if opts.locale == "" && opts.messagesFile == "" {
+		return nil, nil
+	}
+
+	var messages map[string]string
+	if opts.messagesFile != "" {
+		var err error
+		messages, err = lang.ReadMessagesFile(opts.messagesFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return lang.NewCatalog(opts.locale, messages)
+}
+
 func resolveHeader(opts commandOptions) (string, error) {
 	if opts.header != "" {
 		return opts.header, nil
@@ -409,7 +745,7 @@ func resolveFooter(opts commandOptions) (string, error) {
 	return "", nil
 }
 
-func loadPackages(specs []*PackageSpec, opts commandOptions) error {
+func resolveBuildPackages(specs []*PackageSpec, opts commandOptions) error {
 	for _, spec := range specs {
 		log := logger.New(getLogLevel(opts.verbosity), logger.WithField("dir", spec.Dir))
 
@@ -424,23 +760,141 @@ func loadPackages(specs []*PackageSpec, opts commandOptions) error {
 			return err
 		}
 
-		var pkgOpts []lang.PackageOption
-		pkgOpts = append(pkgOpts, lang.PackageWithRepositoryOverrides(&opts.repository))
-		pkgOpts = append(pkgOpts, lang.PackageWithIncludeFiles(opts.includeFiles))
+		spec.buildPkg = buildPkg
+	}
+
+	return nil
+}
 
-		if opts.includeUnexported {
-			pkgOpts = append(pkgOpts, lang.PackageWithUnexportedIncluded())
+// resolvePinnedRef resolves the ref which source links are pinned to with
+// --repository.pin once for all of the packages and sets it as the ref of the
+// repository overrides. Resolving it for each package would walk the history
+// of the repository again for every one of them. The ref is resolved from the
+// repository containing the first package.
+func resolvePinnedRef(specs []*PackageSpec, opts *commandOptions) {
+	kind := lang.RefKind(opts.repositoryPin)
+	if opts.repository.Ref != "" || (kind != lang.CommitRef && kind != lang.TagRef) {
+		return
+	}
+
+	for _, spec := range specs {
+		if spec.buildPkg == nil {
+			continue
 		}
 
-		pkg, err := lang.NewPackageFromBuild(log, buildPkg, pkgOpts...)
+		log := logger.New(getLogLevel(opts.verbosity), logger.WithField("dir", spec.Dir))
+
+		ref, resolvedKind, err := lang.ResolveRef(spec.buildPkg.Dir, kind)
+		if err != nil {
+			log.Infof("unable to resolve %s for source links, using the default branch instead: %s", kind, err)
+			opts.repositoryPin = ""
+			return
+		}
+
+		if resolvedKind != kind {
+			log.Infof("no tag found for source links, using commit %s instead", ref)
+		}
+
+		log.Debugf("resolved %s %s for source links", resolvedKind, ref)
+		opts.repository.Ref = ref
+		opts.repository.RefKind = resolvedKind
+		return
+	}
+}
+
+func loadPackages(specs []*PackageSpec, opts commandOptions, shared *sharedPackages) error {
+	catalog, err := loadCatalog(opts)
+	if err != nil {
+		return err
+	}
+
+	order, err := lang.ParseOrder(opts.order)
+	if err != nil {
+		return err
+	}
+
+	if _, err := lang.ParseRefKind(opts.repositoryPin); err != nil {
+		return err
+	}
+
+	return runParallel(opts.jobs, len(specs), func(i int) error {
+		spec := specs[i]
+
+		// Packages without build information were skipped during resolution
+		// and cached packages don't need to be loaded again, unless they are
+		// needed for the index.
+		if spec.buildPkg == nil || (spec.cached && !spec.loadForIndex) {
+			return nil
+		}
+
+		pkg, err := loadSharedPackage(spec, opts, catalog, order, shared)
 		if err != nil {
 			return err
 		}
 
 		spec.pkg = pkg
+		return nil
+	})
+}
+
+// loadSharedPackage loads the package of the spec unless the same package was
+// already loaded with the same options by a previous target or embed region.
+func loadSharedPackage(spec *PackageSpec, opts commandOptions, catalog *lang.Catalog, order lang.Order, shared *sharedPackages) (*lang.Package, error) {
+	key := fmt.Sprintf(
+		"%s\x00%s\x00%s\x00%s\x00%s\x00%s",
+		spec.buildPkg.Dir,
+		strings.Join(opts.includeFiles, ","),
+		opts.locale,
+		opts.messagesFile,
+		order,
+		strings.Join(opts.groupOrder, ","),
+	)
+
+	return shared.load(key, func() (*lang.Package, error) {
+		return loadPackage(spec, opts, catalog, order)
+	})
+}
+
+func loadPackage(spec *PackageSpec, opts commandOptions, catalog *lang.Catalog, order lang.Order) (*lang.Package, error) {
+	log := logger.New(getLogLevel(opts.verbosity), logger.WithField("dir", spec.Dir))
+
+	// Each package gets its own copy of the repository overrides, since the
+	// config resolution normalizes them in place. The kind of a pinned ref is
+	// known once it has been resolved by resolvePinnedRef.
+	repository := opts.repository
+	if repository.RefKind == "" {
+		repository.RefKind = lang.RefKind(opts.repositoryPin)
 	}
 
-	return nil
+	var pkgOpts []lang.PackageOption
+	pkgOpts = append(pkgOpts, lang.PackageWithRepositoryOverrides(&repository))
+	pkgOpts = append(pkgOpts, lang.PackageWithIncludeFiles(opts.includeFiles))
+
+	if opts.includeUnexported {
+		pkgOpts = append(pkgOpts, lang.PackageWithUnexportedIncluded())
+	}
+
+	if opts.excludeDeprecated {
+		pkgOpts = append(pkgOpts, lang.PackageWithDeprecatedExcluded())
+	}
+
+	if catalog != nil {
+		pkgOpts = append(pkgOpts, lang.PackageWithCatalog(catalog))
+	}
+
+	if order != lang.DefaultOrder {
+		pkgOpts = append(pkgOpts, lang.PackageWithOrder(order))
+	}
+
+	if len(opts.groupOrder) > 0 {
+		pkgOpts = append(pkgOpts, lang.PackageWithGroupOrder(opts.groupOrder))
+	}
+
+	if len(opts.repositoryRemotes) > 0 {
+		pkgOpts = append(pkgOpts, lang.PackageWithRemoteNames(opts.repositoryRemotes))
+	}
+
+	return lang.NewPackageFromBuild(log, spec.buildPkg, pkgOpts...)
 }
 
 func getBuildPackage(path string, tags []string) (*build.Package, error) {
@@ -613,14 +1067,17 @@ func getLogLevel(verbosity int) logger.Level {
 }
 
 func printVersion() {
+	fmt.Println(getVersion())
+}
+
+func getVersion() string {
 	if version != "" {
-		fmt.Println(version)
-		return
+		return version
 	}
 
 	if info, ok := debug.ReadBuildInfo(); ok {
-		fmt.Println(info.Main.Version)
-	} else {
-		fmt.Println("<unknown>")
+		return info.Main.Version
 	}
+
+	return "<unknown>"
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"go/doc"
 	"io"
 	"os"
 	"path/filepath"
@@ -10,6 +11,9 @@ import (
 	"testing"
 
 	"github.com/matryer/is"
+
+	"github.com/cloudogu/gomarkdoc"
+	"github.com/cloudogu/gomarkdoc/lang"
 )
 
 var wd, _ = os.Getwd()
@@ -382,3 +386,43 @@ func verifyNotEqual(t *testing.T, dir string) {
 func cleanup(dir string) {
 	os.Remove(filepath.Join(dir, "README-test.md"))
 }
+
+func TestResolveOverrides_calloutStyle(t *testing.T) {
+	is := is.New(t)
+
+	overrides, err := resolveOverrides(commandOptions{format: "plain", calloutStyle: "aside"})
+	is.NoErr(err)
+
+	out, err := gomarkdoc.NewRenderer(overrides...)
+	is.NoErr(err)
+
+	text, err := out.Package(lang.NewPackage(&lang.Config{Level: 1}, &doc.Package{Name: "example", Doc: "Warning: Handle with care.\n"}, nil))
+	is.NoErr(err)
+	is.True(strings.Contains(text, "<aside class=\"warning\">\n\n**Warning:** Handle with care.\n\n</aside>"))
+
+	_, err = resolveOverrides(commandOptions{format: "plain", calloutStyle: "box"})
+	is.Equal(err.Error(), "gomarkdoc: invalid callout style: box")
+}
+
+func TestResolvePinnedRef_noRepository(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/norepo\n\ngo 1.19\n"), 0664))
+	is.NoErr(os.WriteFile(filepath.Join(dir, "pkg.go"), []byte("// Package norepo is documented.\npackage norepo\n"), 0664))
+
+	opts := commandOptions{repositoryPin: "tag"}
+	specs := getSpecs(dir)
+	is.NoErr(resolveBuildPackages(specs, opts))
+
+	// Without a repository, source links fall back to the default branch.
+	resolvePinnedRef(specs, &opts)
+	is.Equal(opts.repositoryPin, "")
+	is.Equal(opts.repository.Ref, "")
+
+	// An explicit ref is used as is.
+	opts = commandOptions{repositoryPin: "tag", repository: lang.Repo{Ref: "v1.0.0"}}
+	resolvePinnedRef(specs, &opts)
+	is.Equal(opts.repositoryPin, "tag")
+	is.Equal(opts.repository.Ref, "v1.0.0")
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/spf13/cobra"
+
+	"github.com/cloudogu/gomarkdoc"
+	"github.com/cloudogu/gomarkdoc/lang"
+	"github.com/cloudogu/gomarkdoc/logger"
+)
+
+func buildDiffCommand(configFile *string) *cobra.Command {
+	var output string
+
+	var command = &cobra.Command{
+		Use:   "diff <rev1> <rev2> [package ...]",
+		Short: "report the changes to the documented API between two git revisions",
+		Long: "Report the changes to the documented API of the packages between two git revisions, such as the " +
+			"tags of two releases. Added, removed and changed types, struct fields, struct tags and " +
+			"documentation are listed. The packages are local paths within the git repository of the " +
+			"current directory and default to the current directory. The report is rendered with the " +
+			"apidiff template.",
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			buildConfig(*configFile)
+
+			var opts commandOptions
+			loadOptions(&opts)
+
+			out, err := newRenderer(opts)
+			if err != nil {
+				return err
+			}
+
+			text, err := runDiff(args[0], args[1], args[2:], opts, out)
+			if err != nil {
+				return err
+			}
+
+			if output == "" {
+				_, err := io.WriteString(cmd.OutOrStdout(), text)
+				return err
+			}
+
+			if err := writeFile(output, text); err != nil {
+				return fmt.Errorf("failed to write output file %s: %w", output, err)
+			}
+
+			return nil
+		},
+	}
+
+	command.Flags().StringVarP(
+		&output,
+		"output",
+		"o",
+		"",
+		"File to which the report is written. Defaults to printing to stdout.",
+	)
+
+	return command
+}
+
+// runDiff renders the report of the changes to the documented API of the
+// packages at the provided paths between two revisions of the git repository
+// containing the current directory.
+func runDiff(from, to string, paths []string, opts commandOptions, out *gomarkdoc.Renderer) (string, error) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	for _, path := range paths {
+		if path != "." && !isLocalPath(path) {
+			return "", fmt.Errorf("gomarkdoc: diff only supports local package paths, got %s", path)
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	repo, err := git.PlainOpenWithOptions(wd, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: diff must be run within a git repository: %w", err)
+	}
+
+	rel, err := repoRelativeDir(repo, wd)
+	if err != nil {
+		return "", err
+	}
+
+	oldKeys, oldPkgs, err := loadRevision(repo, from, rel, paths, opts)
+	if err != nil {
+		return "", err
+	}
+
+	newKeys, newPkgs, err := loadRevision(repo, to, rel, paths, opts)
+	if err != nil {
+		return "", err
+	}
+
+	// Packages are reported in the order of the newer revision, followed by
+	// the packages which were removed.
+	keys := newKeys
+	for _, key := range oldKeys {
+		if _, ok := newPkgs[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	var diffs []*lang.PackageDiff
+	for _, key := range keys {
+		if diff := lang.DiffPackages(oldPkgs[key], newPkgs[key]); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	header, err := resolveHeader(opts)
+	if err != nil {
+		return "", err
+	}
+
+	footer, err := resolveFooter(opts)
+	if err != nil {
+		return "", err
+	}
+
+	return out.APIDiff(lang.NewAPIDiff(header, footer, from, to, diffs))
+}
+
+// repoRelativeDir provides the path of the directory relative to the root of
+// the worktree of the repository.
+func repoRelativeDir(repo *git.Repository, dir string) (string, error) {
+	wt, err := repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: diff requires a repository with a worktree: %w", err)
+	}
+
+	root, err := filepath.EvalSymlinks(wt.Filesystem.Root())
+	if err != nil {
+		return "", err
+	}
+
+	dir, err = filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Rel(root, dir)
+}
+
+// loadRevision checks out the revision into a temporary directory and loads
+// the packages at the provided paths, which are relative to the directory rel
+// within the repository. The packages are keyed by their directory relative to
+// rel, and the keys are returned in the order in which the packages were found.
+// Packages which don't exist at the revision are left out.
+func loadRevision(repo *git.Repository, rev, rel string, paths []string, opts commandOptions) ([]string, map[string]*lang.Package, error) {
+	log := logger.New(getLogLevel(opts.verbosity), logger.WithField("revision", rev))
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, nil, fmt.Errorf("gomarkdoc: unable to resolve revision %s: %w", rev, err)
+	}
+
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("gomarkdoc: unable to resolve revision %s: %w", rev, err)
+	}
+
+	dir, err := ioutil.TempDir("", "gomarkdoc-diff-")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	log.Debugf("checking out %s into %s", hash, dir)
+	if err := checkoutCommit(commit, dir); err != nil {
+		return nil, nil, fmt.Errorf("gomarkdoc: unable to check out revision %s: %w", rev, err)
+	}
+
+	base := filepath.Join(dir, rel)
+	revPaths := make([]string, len(paths))
+	for i, path := range paths {
+		revPaths[i] = filepath.Join(base, filepath.FromSlash(path))
+	}
+
+	specs := getSpecs(revPaths...)
+	for _, spec := range specs {
+		buildPkg, err := getBuildPackage(spec.ImportPath, opts.tags)
+		if err != nil {
+			log.Debugf("no package found in directory %s: %s", spec.Dir, err)
+			continue
+		}
+
+		spec.buildPkg = buildPkg
+	}
+
+	resolvePinnedRef(specs, &opts)
+
+	if err := loadPackages(specs, opts, &sharedPackages{pkgs: make(map[string]*lang.Package)}); err != nil {
+		return nil, nil, err
+	}
+
+	var keys []string
+	pkgs := make(map[string]*lang.Package)
+	for _, spec := range specs {
+		if spec.pkg == nil {
+			continue
+		}
+
+		key, err := filepath.Rel(base, spec.buildPkg.Dir)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if _, ok := pkgs[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		pkgs[key] = spec.pkg
+	}
+
+	return keys, pkgs, nil
+}
+
+// checkoutCommit writes the files of the commit to the directory. Symbolic
+// links and submodules are skipped.
+func checkoutCommit(commit *object.Commit, dir string) error {
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+
+	return tree.Files().ForEach(func(f *object.File) error {
+		if f.Mode == filemode.Symlink || f.Mode == filemode.Submodule {
+			return nil
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(f.Name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+
+		r, err := f.Reader()
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		w, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(w, r); err != nil {
+			w.Close()
+			return err
+		}
+
+		return w.Close()
+	})
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/matryer/is"
+
+	"github.com/cloudogu/gomarkdoc"
+)
+
+func TestRunDiff(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	is.NoErr(err)
+
+	commit := func(files map[string]string, msg string) string {
+		wt, err := repo.Worktree()
+		is.NoErr(err)
+
+		for name, content := range files {
+			path := filepath.Join(dir, name)
+			is.NoErr(os.MkdirAll(filepath.Dir(path), 0755))
+			is.NoErr(os.WriteFile(path, []byte(content), 0664))
+
+			_, err := wt.Add(name)
+			is.NoErr(err)
+		}
+
+		hash, err := wt.Commit(msg, &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Unix(0, 0)},
+		})
+		is.NoErr(err)
+
+		return hash.String()
+	}
+
+	from := commit(map[string]string{
+		"go.mod": "module example.com/diff\n\ngo 1.19\n",
+		"config/config.go": "// Package config holds the configuration.\npackage config\n\n" +
+			"// Config is the configuration.\ntype Config struct {\n\tName string `json:\"name\"`\n}\n",
+	}, "first")
+
+	to := commit(map[string]string{
+		"config/config.go": "// Package config holds the configuration.\npackage config\n\n" +
+			"// Config is the configuration.\ntype Config struct {\n\tName string `json:\"name\"`\n\n" +
+			"\t// Port is the port.\n\tPort int `json:\"port\"`\n}\n",
+		"server/server.go": "// Package server serves.\npackage server\n",
+	}, "second")
+
+	is.NoErr(os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	out, err := gomarkdoc.NewRenderer()
+	is.NoErr(err)
+
+	text, err := runDiff(from, to, []string{"./..."}, commandOptions{}, out)
+	is.NoErr(err)
+	is.True(strings.Contains(text, "## package example.com/diff/config\n\n### type Config\n\n- Field `Port` of type `int` with tag `json:\"port\"` was added."))
+	is.True(strings.Contains(text, "## package example.com/diff/server\n\nThe package was added."))
+
+	text, err = runDiff(to, from, []string{"./server"}, commandOptions{}, out)
+	is.NoErr(err)
+	is.True(strings.Contains(text, "## package example.com/diff/server\n\nThe package was removed."))
+
+	text, err = runDiff(from, from, nil, commandOptions{}, out)
+	is.NoErr(err)
+	is.True(strings.Contains(text, "The documented API did not change."))
+
+	_, err = runDiff(from, "unknown", nil, commandOptions{}, out)
+	is.True(err != nil)
+
+	_, err = runDiff(from, to, []string{"example.com/diff"}, commandOptions{}, out)
+	is.Equal(err.Error(), "gomarkdoc: diff only supports local package paths, got example.com/diff")
+}
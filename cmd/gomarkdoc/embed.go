@@ -0,0 +1,504 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cloudogu/gomarkdoc"
+	"github.com/cloudogu/gomarkdoc/lang"
+	"github.com/cloudogu/gomarkdoc/logger"
+)
+
+// embedAttrs holds the attributes of an embed marker, which select what is
+// rendered into the region of the marker. A region without attributes holds
+// the documentation of all packages written to the file.
+type embedAttrs struct {
+	// pkg is the package to document. Local paths are relative to the
+	// directory of the file containing the marker.
+	pkg string
+
+	// typ is the name of a single type to document.
+	typ string
+
+	// template is the name of the template used to render the region.
+	template string
+}
+
+// embedAttrRegex matches a single key=value attribute of an embed marker. The
+// value may be quoted to include spaces.
+var embedAttrRegex = regexp.MustCompile(`^([a-zA-Z]+)=("(?:[^"\\]|\\.)*"|[^\s"]+)`)
+
+// parseEmbedAttrs parses the attributes of an embed marker, such as
+// pkg=./config type=Settings template=type.
+func parseEmbedAttrs(s string) (embedAttrs, error) {
+	var attrs embedAttrs
+
+	s = strings.TrimSpace(s)
+	for s != "" {
+		m := embedAttrRegex.FindStringSubmatch(s)
+		if m == nil {
+			return attrs, fmt.Errorf("invalid attribute %q, expected key=value", strings.Fields(s)[0])
+		}
+
+		value := m[2]
+		if strings.HasPrefix(value, `"`) {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return attrs, fmt.Errorf("invalid value for attribute %s: %w", m[1], err)
+			}
+
+			value = unquoted
+		}
+
+		switch m[1] {
+		case "pkg":
+			attrs.pkg = value
+		case "type":
+			attrs.typ = value
+		case "template":
+			attrs.template = value
+		default:
+			return attrs, fmt.Errorf("unknown attribute %q. Valid attributes: pkg, type, template", m[1])
+		}
+
+		s = strings.TrimSpace(s[len(m[0]):])
+	}
+
+	return attrs, nil
+}
+
+// String provides the attributes in the form used by embed markers.
+func (a embedAttrs) String() string {
+	var parts []string
+	add := func(key, value string) {
+		if value == "" {
+			return
+		}
+
+		if strings.ContainsAny(value, " \t\"\\>") {
+			value = strconv.Quote(value)
+		}
+
+		parts = append(parts, key+"="+value)
+	}
+
+	add("pkg", a.pkg)
+	add("type", a.typ)
+	add("template", a.template)
+
+	return strings.Join(parts, " ")
+}
+
+// embedSyntax describes the comments holding the embed markers of a kind of
+// file.
+type embedSyntax struct {
+	// open and close delimit the comments holding the markers. Line comments
+	// have no closing delimiter.
+	open, close string
+
+	// commented defines whether the embedded documentation is commented out
+	// with line comments as well, such as in Go and YAML files.
+	commented bool
+
+	// regex matches a single embed marker on its own line. The indentation of
+	// the marker is captured by the first group, the kind of the marker such
+	// as ":start" by the second group and its attributes by the third group.
+	regex *regexp.Regexp
+}
+
+var (
+	htmlEmbedSyntax     = newEmbedSyntax("<!--", "-->", false)
+	asciidocEmbedSyntax = newEmbedSyntax("//", "", false)
+	goEmbedSyntax       = newEmbedSyntax("//", "", true)
+	hashEmbedSyntax     = newEmbedSyntax("#", "", true)
+)
+
+// embedSyntaxes maps file extensions to the syntax of their embed markers.
+// Files with other extensions use HTML comments like markdown files.
+var embedSyntaxes = map[string]*embedSyntax{
+	".adoc":     asciidocEmbedSyntax,
+	".asciidoc": asciidocEmbedSyntax,
+	".go":       goEmbedSyntax,
+	".py":       hashEmbedSyntax,
+	".sh":       hashEmbedSyntax,
+	".toml":     hashEmbedSyntax,
+	".yaml":     hashEmbedSyntax,
+	".yml":      hashEmbedSyntax,
+}
+
+func newEmbedSyntax(open, close string, commented bool) *embedSyntax {
+	var expr string
+	if close == "" {
+		expr = fmt.Sprintf(
+			`(?m)^([ \t]*)%s[ \t]*gomarkdoc:embed(:[a-zA-Z]+)?(?:[ \t]+([^\n]*?))?[ \t]*$`,
+			regexp.QuoteMeta(open),
+		)
+	} else {
+		expr = fmt.Sprintf(
+			`(?m)^( *)%s\s*gomarkdoc:embed(:[a-zA-Z]+)?(?:\s([^>]*?))?\s*%s[ \t]*$`,
+			regexp.QuoteMeta(open),
+			regexp.QuoteMeta(close),
+		)
+	}
+
+	return &embedSyntax{open: open, close: close, commented: commented, regex: regexp.MustCompile(expr)}
+}
+
+// embedSyntaxFor provides the syntax of the embed markers of the file, which
+// is selected by its extension.
+func embedSyntaxFor(fileName string) *embedSyntax {
+	if syntax, ok := embedSyntaxes[strings.ToLower(filepath.Ext(fileName))]; ok {
+		return syntax
+	}
+
+	return htmlEmbedSyntax
+}
+
+// marker provides the comment holding the marker.
+func (s *embedSyntax) marker(indent, marker string) string {
+	if s.close != "" {
+		return fmt.Sprintf("%s%s %s %s", indent, s.open, marker, s.close)
+	}
+
+	return fmt.Sprintf("%s%s %s", indent, s.open, marker)
+}
+
+// content provides the documentation as embedded into the file, which is
+// commented out line by line for syntaxes which require it.
+func (s *embedSyntax) content(indent, text string) string {
+	if !s.commented {
+		return text
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = indent + s.open
+		} else {
+			lines[i] = fmt.Sprintf("%s%s %s", indent, s.open, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// region wraps the rendered documentation of a region in a pair of start and
+// end markers holding the attributes of the region, so that the region is
+// found again on later runs. The indentation of the marker is only kept for
+// commented documentation, since it would change the meaning of markdown.
+func (s *embedSyntax) region(indent string, attrs embedAttrs, text string) string {
+	if !s.commented {
+		indent = ""
+	}
+
+	start := "gomarkdoc:embed:start"
+	if a := attrs.String(); a != "" {
+		start = fmt.Sprintf("%s %s", start, a)
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		s.marker(indent, start),
+		s.content(indent, "\n"+text+"\n"),
+		s.marker(indent, "gomarkdoc:embed:end"),
+	)
+}
+
+// embedRegion is a region of a file into which documentation is embedded.
+// It is either defined by a standalone marker or by a pair of start and end
+// markers, which are replaced along with the content between them.
+type embedRegion struct {
+	// start and end are the offsets of the region within the file.
+	start, end int
+
+	// line is the line of the marker starting the region.
+	line int
+
+	// indent is the indentation of the marker starting the region.
+	indent string
+
+	attrs embedAttrs
+}
+
+// parseEmbedRegions finds the embed regions of the file data. Unbalanced
+// markers, nested or duplicate start markers and markers with invalid
+// attributes are reported with their line numbers. All problems of the file
+// are reported together.
+func parseEmbedRegions(fileName string, data []byte, syntax *embedSyntax) ([]embedRegion, error) {
+	var (
+		regions []embedRegion
+		open    *embedRegion
+		errs    multiError
+	)
+
+	fail := func(line int, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("gomarkdoc: %s:%d: %s", fileName, line, fmt.Sprintf(format, args...)))
+	}
+
+	for _, m := range syntax.regex.FindAllSubmatchIndex(data, -1) {
+		indent := string(data[m[2]:m[3]])
+		line := 1 + bytes.Count(data[:m[3]], []byte("\n"))
+
+		var kind, rawAttrs string
+		if m[4] >= 0 {
+			kind = string(data[m[4]:m[5]])
+		}
+
+		if m[6] >= 0 {
+			rawAttrs = string(data[m[6]:m[7]])
+		}
+
+		switch kind {
+		case "", ":start":
+			attrs, err := parseEmbedAttrs(rawAttrs)
+			if err != nil {
+				fail(line, "invalid embed marker: %s", err)
+			}
+
+			if open != nil {
+				fail(line, "embed marker inside the region started at line %d, which has no end marker", open.line)
+			}
+
+			region := embedRegion{start: m[0], end: m[1], line: line, indent: indent, attrs: attrs}
+			if kind == "" {
+				open = nil
+				regions = append(regions, region)
+			} else {
+				open = &region
+			}
+		case ":end":
+			if strings.TrimSpace(rawAttrs) != "" {
+				fail(line, "embed end marker does not take attributes")
+			}
+
+			if open == nil {
+				fail(line, "embed end marker without start marker")
+				continue
+			}
+
+			open.end = m[1]
+			regions = append(regions, *open)
+			open = nil
+		default:
+			fail(line, "unknown embed marker gomarkdoc:embed%s", kind)
+		}
+	}
+
+	if open != nil {
+		fail(open.line, "embed start marker without end marker")
+	}
+
+	switch len(errs) {
+	case 0:
+		return regions, nil
+	case 1:
+		return nil, errs[0]
+	default:
+		return nil, errs
+	}
+}
+
+// findEmbedAttrs provides the attributes of all embed regions of the file.
+func findEmbedAttrs(fileName string, data []byte) ([]embedAttrs, error) {
+	regions, err := parseEmbedRegions(fileName, data, embedSyntaxFor(fileName))
+	if err != nil {
+		return nil, err
+	}
+
+	found := make([]embedAttrs, len(regions))
+	for i, region := range regions {
+		found[i] = region.attrs
+	}
+
+	return found, nil
+}
+
+// embedContents renders the documentation into the embed regions of the
+// existing file. Each region is rendered independently by the provided
+// function. If the file doesn't exist, it consists of a single region holding
+// the documentation of all packages. If the file has no embed markers, such a
+// region is appended to the end of the file instead. The syntax of the
+// markers depends on the extension of the file, e.g. HTML comments for
+// markdown files and line comments for Go and YAML files.
+//
+// Malformed markers are reported as errors without rendering anything. Since
+// each region is written with its start and end markers, embedding again
+// replaces the same regions, which leaves the file unchanged as long as the
+// documentation did not change.
+func embedContents(log logger.Logger, fileName string, render func(attrs embedAttrs) (string, error)) (string, error) {
+	syntax := embedSyntaxFor(fileName)
+
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		log.Debugf("unable to find output file %s for embedding. Creating a new file instead", fileName)
+
+		return renderRegion(fileName, syntax, embedRegion{line: 1}, render)
+	}
+
+	regions, err := parseEmbedRegions(fileName, data, syntax)
+	if err != nil {
+		return "", err
+	}
+
+	if len(regions) == 0 {
+		log.Debugf("no embed markers found. Appending documentation to the end of the file instead")
+
+		text, err := renderRegion(fileName, syntax, embedRegion{line: 1 + bytes.Count(data, []byte("\n"))}, render)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("%s\n\n%s", string(data), text), nil
+	}
+
+	var (
+		b      strings.Builder
+		cursor int
+	)
+
+	for _, region := range regions {
+		text, err := renderRegion(fileName, syntax, region, render)
+		if err != nil {
+			return "", err
+		}
+
+		b.Write(data[cursor:region.start])
+		b.WriteString(text)
+		cursor = region.end
+	}
+
+	b.Write(data[cursor:])
+
+	return b.String(), nil
+}
+
+// renderRegion renders the documentation of the region along with its
+// markers. Documentation which contains embed markers itself, such as examples
+// of the markers, is rejected, since the region could not be found again on
+// later runs.
+func renderRegion(fileName string, syntax *embedSyntax, region embedRegion, render func(attrs embedAttrs) (string, error)) (string, error) {
+	text, err := render(region.attrs)
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: %s:%d: failed to render embed region %q: %w", fileName, region.line, region.attrs.String(), err)
+	}
+
+	text = syntax.region(region.indent, region.attrs, text)
+	if markers := syntax.regex.FindAllIndex([]byte(text), -1); len(markers) != 2 {
+		return "", fmt.Errorf(
+			"gomarkdoc: %s:%d: the documentation of embed region %q contains embed markers and can't be embedded",
+			fileName,
+			region.line,
+			region.attrs.String(),
+		)
+	}
+
+	return text, nil
+}
+
+// regionRenderer renders the embed regions of the output files.
+type regionRenderer struct {
+	out      *gomarkdoc.Renderer
+	opts     commandOptions
+	catalog  *lang.Catalog
+	order    lang.Order
+	shared   *sharedPackages
+	fullText func(fileName string) (string, error)
+	filePkgs map[string][]*lang.Package
+}
+
+// render renders the region with the provided attributes of the output file.
+// The data passed to the template of the region depends on the attributes:
+// the type if a type is selected, otherwise the package if a package is
+// selected and otherwise the file with all of its packages. The template
+// defaults to the "type", "package" and "file" template respectively.
+func (r *regionRenderer) render(fileName string, attrs embedAttrs) (string, error) {
+	if attrs == (embedAttrs{}) {
+		return r.fullText(fileName)
+	}
+
+	pkgs := r.filePkgs[fileName]
+	if attrs.pkg != "" {
+		pkg, err := r.loadPackage(fileName, attrs.pkg)
+		if err != nil {
+			return "", err
+		}
+
+		pkgs = []*lang.Package{pkg}
+	}
+
+	if attrs.typ != "" {
+		for _, pkg := range pkgs {
+			for _, typ := range pkg.Types() {
+				if typ.Name() == attrs.typ {
+					return r.out.Template(valueOrDefault(attrs.template, "type"), typ)
+				}
+			}
+		}
+
+		return "", fmt.Errorf("gomarkdoc: type %s not found", attrs.typ)
+	}
+
+	if attrs.pkg != "" {
+		return r.out.Template(valueOrDefault(attrs.template, "package"), pkgs[0])
+	}
+
+	header, err := resolveHeader(r.opts)
+	if err != nil {
+		return "", err
+	}
+
+	footer, err := resolveFooter(r.opts)
+	if err != nil {
+		return "", err
+	}
+
+	return r.out.Template(attrs.template, lang.NewFile(header, footer, pkgs))
+}
+
+// loadPackage loads the package referenced by an embed region of the output
+// file.
+func (r *regionRenderer) loadPackage(fileName, path string) (*lang.Package, error) {
+	spec, err := regionSpec(fileName, path, r.opts.tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadSharedPackage(spec, r.opts, r.catalog, r.order, r.shared)
+}
+
+// regionSpec resolves the package referenced by an embed region of the output
+// file. Local paths are relative to the directory of the file.
+func regionSpec(fileName, path string, tags []string) (*PackageSpec, error) {
+	if isLocalPath(path) && !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(fileName), path)
+		if !isLocalPath(path) {
+			path = cwdPathPrefix + path
+		}
+	}
+
+	specs := getSpecs(path)
+	if len(specs) != 1 || specs[0].isWildcard {
+		return nil, fmt.Errorf("gomarkdoc: embed regions must reference a single package, got %s", path)
+	}
+
+	buildPkg, err := getBuildPackage(specs[0].ImportPath, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	specs[0].buildPkg = buildPkg
+	return specs[0], nil
+}
+
+func valueOrDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+
+	return s
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudogu/gomarkdoc/lang"
+	"github.com/cloudogu/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestParseEmbedAttrs(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  embedAttrs
+		err   string
+	}{
+		{"empty", "", embedAttrs{}, ""},
+		{"package", " pkg=./config ", embedAttrs{pkg: "./config"}, ""},
+		{
+			"all",
+			"pkg=./config type=Settings template=structfield",
+			embedAttrs{pkg: "./config", typ: "Settings", template: "structfield"},
+			"",
+		},
+		{"quoted", `template="my template"`, embedAttrs{template: "my template"}, ""},
+		{"unknown", "kind=type", embedAttrs{}, `unknown attribute "kind"`},
+		{"missing value", "pkg", embedAttrs{}, `invalid attribute "pkg"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+
+			attrs, err := parseEmbedAttrs(test.input)
+			if test.err != "" {
+				is.True(err != nil)
+				is.True(strings.Contains(err.Error(), test.err))
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(attrs, test.want)
+
+			// The canonical form parses to the same attributes
+			reparsed, err := parseEmbedAttrs(attrs.String())
+			is.NoErr(err)
+			is.Equal(reparsed, attrs)
+		})
+	}
+}
+
+func TestEmbedContents(t *testing.T) {
+	is := is.New(t)
+
+	fileName := filepath.Join(t.TempDir(), "README.md")
+	log := logger.New(logger.ErrorLevel)
+	render := func(attrs embedAttrs) (string, error) {
+		if attrs == (embedAttrs{}) {
+			return "all", nil
+		}
+
+		return "region " + attrs.String(), nil
+	}
+
+	// A missing file consists of a single region
+	text, err := embedContents(log, fileName, render)
+	is.NoErr(err)
+	is.Equal(text, "<!-- gomarkdoc:embed:start -->\n\nall\n\n<!-- gomarkdoc:embed:end -->")
+
+	is.NoErr(os.WriteFile(fileName, []byte(`before
+
+<!-- gomarkdoc:embed type=Settings -->
+
+between
+
+<!-- gomarkdoc:embed:start pkg=./config template="my template" -->
+
+outdated
+
+<!-- gomarkdoc:embed:end -->
+
+after
+`), 0664))
+
+	text, err = embedContents(log, fileName, render)
+	is.NoErr(err)
+	is.Equal(text, `before
+
+<!-- gomarkdoc:embed:start type=Settings -->
+
+region type=Settings
+
+<!-- gomarkdoc:embed:end -->
+
+between
+
+<!-- gomarkdoc:embed:start pkg=./config template="my template" -->
+
+region pkg=./config template="my template"
+
+<!-- gomarkdoc:embed:end -->
+
+after
+`)
+
+	// Embedding is idempotent
+	is.NoErr(os.WriteFile(fileName, []byte(text), 0664))
+	again, err := embedContents(log, fileName, render)
+	is.NoErr(err)
+	is.Equal(again, text)
+
+	// Errors name the file and region
+	_, err = embedContents(log, fileName, func(attrs embedAttrs) (string, error) {
+		return "", errors.New("broken")
+	})
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), fileName))
+	is.True(strings.Contains(err.Error(), "type=Settings"))
+}
+
+func TestRegionRenderer(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "README.md")
+	pkgDir := filepath.Join(wd, "../../testData/lang/order")
+
+	opts := commandOptions{
+		format:     "plain",
+		repository: lang.Repo{Remote: "https://example.com/repo", DefaultBranch: "main", PathFromRoot: "/"},
+	}
+
+	out, err := newRenderer(opts)
+	is.NoErr(err)
+
+	r := &regionRenderer{
+		out:      out,
+		opts:     opts,
+		shared:   &sharedPackages{pkgs: make(map[string]*lang.Package)},
+		filePkgs: make(map[string][]*lang.Package),
+	}
+
+	text, err := r.render(fileName, embedAttrs{pkg: pkgDir, typ: "Config"})
+	is.NoErr(err)
+	is.True(strings.Contains(text, "Config"))
+	is.True(!strings.Contains(text, "type ServerConfig"))
+
+	_, err = r.render(fileName, embedAttrs{pkg: pkgDir, typ: "Missing"})
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "type Missing not found"))
+
+	_, err = r.render(fileName, embedAttrs{pkg: pkgDir + "/..."})
+	is.True(err != nil)
+
+	_, err = r.render(fileName, embedAttrs{pkg: pkgDir, template: "unknown"})
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), `unknown template "unknown"`))
+}
+
+func TestRegionSpec(t *testing.T) {
+	is := is.New(t)
+
+	fileName := filepath.Join(wd, "../../testData/README.md")
+
+	spec, err := regionSpec(fileName, "./lang/order", nil)
+	is.NoErr(err)
+	is.Equal(spec.buildPkg.Name, "order")
+}
+
+func TestEmbedContents_syntaxes(t *testing.T) {
+	render := func(attrs embedAttrs) (string, error) {
+		return "# Title\n\ncontent", nil
+	}
+
+	tests := []struct {
+		name     string
+		fileName string
+		input    string
+		want     string
+	}{
+		{
+			"html",
+			"index.html",
+			"<p>before</p>\n<!-- gomarkdoc:embed -->\n<p>after</p>\n",
+			"<p>before</p>\n<!-- gomarkdoc:embed:start -->\n\n# Title\n\ncontent\n\n<!-- gomarkdoc:embed:end -->\n<p>after</p>\n",
+		},
+		{
+			"asciidoc",
+			"README.adoc",
+			"= Doc\n\n// gomarkdoc:embed:start type=Settings\nold\n// gomarkdoc:embed:end\n\nafter\n",
+			"= Doc\n\n// gomarkdoc:embed:start type=Settings\n\n# Title\n\ncontent\n\n// gomarkdoc:embed:end\n\nafter\n",
+		},
+		{
+			"go",
+			"doc.go",
+			"// Package config does things.\n//\n// gomarkdoc:embed\npackage config\n",
+			"// Package config does things.\n//\n// gomarkdoc:embed:start\n//\n// # Title\n//\n// content\n//\n// gomarkdoc:embed:end\npackage config\n",
+		},
+		{
+			"yaml",
+			"values.yaml",
+			"server:\n  # gomarkdoc:embed:start type=Server\n  # old\n  # gomarkdoc:embed:end\n  port: 80\n",
+			"server:\n  # gomarkdoc:embed:start type=Server\n  #\n  # # Title\n  #\n  # content\n  #\n  # gomarkdoc:embed:end\n  port: 80\n",
+		},
+		{
+			"no markers",
+			"values.yml",
+			"port: 80",
+			"port: 80\n\n# gomarkdoc:embed:start\n#\n# # Title\n#\n# content\n#\n# gomarkdoc:embed:end",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+
+			fileName := filepath.Join(t.TempDir(), test.fileName)
+			is.NoErr(os.WriteFile(fileName, []byte(test.input), 0664))
+
+			text, err := embedContents(logger.New(logger.ErrorLevel), fileName, render)
+			is.NoErr(err)
+			is.Equal(text, test.want)
+
+			// Embedding again keeps the file unchanged
+			is.NoErr(os.WriteFile(fileName, []byte(text), 0664))
+			again, err := embedContents(logger.New(logger.ErrorLevel), fileName, render)
+			is.NoErr(err)
+			is.Equal(again, text)
+		})
+	}
+}
+
+func TestEmbedContents_malformed(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		errors []string
+	}{
+		{
+			"missing end",
+			"a\n<!-- gomarkdoc:embed:start -->\nb\n",
+			[]string{":2: embed start marker without end marker"},
+		},
+		{
+			"missing start",
+			"a\n\n<!-- gomarkdoc:embed:end -->\n",
+			[]string{":3: embed end marker without start marker"},
+		},
+		{
+			"duplicate start",
+			"<!-- gomarkdoc:embed:start -->\n<!-- gomarkdoc:embed:start -->\n<!-- gomarkdoc:embed:end -->\n",
+			[]string{":2: embed marker inside the region started at line 1, which has no end marker"},
+		},
+		{
+			"nested standalone",
+			"<!-- gomarkdoc:embed:start -->\n<!-- gomarkdoc:embed -->\n<!-- gomarkdoc:embed:end -->\n",
+			[]string{":2: embed marker inside the region started at line 1", ":3: embed end marker without start marker"},
+		},
+		{
+			"unknown marker",
+			"<!-- gomarkdoc:embed:begin -->\n",
+			[]string{":1: unknown embed marker gomarkdoc:embed:begin"},
+		},
+		{
+			"invalid attributes",
+			"<!-- gomarkdoc:embed:start kind=type -->\n<!-- gomarkdoc:embed:end -->\n",
+			[]string{`:1: invalid embed marker: unknown attribute "kind"`},
+		},
+		{
+			"end attributes",
+			"<!-- gomarkdoc:embed:start -->\n<!-- gomarkdoc:embed:end type=Settings -->\n",
+			[]string{":2: embed end marker does not take attributes"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+
+			fileName := filepath.Join(t.TempDir(), "README.md")
+			is.NoErr(os.WriteFile(fileName, []byte(test.input), 0664))
+
+			var rendered bool
+			_, err := embedContents(logger.New(logger.ErrorLevel), fileName, func(attrs embedAttrs) (string, error) {
+				rendered = true
+				return "content", nil
+			})
+			is.True(err != nil)
+			is.True(!rendered) // nothing is rendered for malformed markers
+
+			for _, msg := range test.errors {
+				is.True(strings.Contains(err.Error(), fileName+msg))
+			}
+		})
+	}
+}
+
+func TestEmbedContents_markersInDocumentation(t *testing.T) {
+	is := is.New(t)
+
+	fileName := filepath.Join(t.TempDir(), "README.md")
+	is.NoErr(os.WriteFile(fileName, []byte("<!-- gomarkdoc:embed -->\n"), 0664))
+
+	_, err := embedContents(logger.New(logger.ErrorLevel), fileName, func(attrs embedAttrs) (string, error) {
+		return "Example:\n\n<!-- gomarkdoc:embed:end -->", nil
+	})
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "contains embed markers"))
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/cloudogu/gomarkdoc/logger"
+)
+
+// testEvent is a single event of the output of go test -json.
+type testEvent struct {
+	Action  string
+	Package string
+	Test    string
+	Output  string
+}
+
+// verifyExamples runs the examples of the packages with go test. An error is
+// returned for each example whose output doesn't match the documented output
+// and for each package whose examples fail to build.
+func verifyExamples(specs []*PackageSpec, opts commandOptions) error {
+	return runParallel(opts.jobs, len(specs), func(i int) error {
+		spec := specs[i]
+
+		// Examples are only found in test files.
+		if spec.buildPkg == nil || len(spec.buildPkg.TestGoFiles)+len(spec.buildPkg.XTestGoFiles) == 0 {
+			return nil
+		}
+
+		return verifyPackageExamples(spec, opts)
+	})
+}
+
+func verifyPackageExamples(spec *PackageSpec, opts commandOptions) error {
+	log := logger.New(getLogLevel(opts.verbosity), logger.WithField("dir", spec.Dir))
+
+	args := []string{"test", "-json", "-vet=off", "-count=1", "-run", "^Example"}
+	if len(opts.tags) > 0 {
+		args = append(args, "-tags", strings.Join(opts.tags, ","))
+	}
+	args = append(args, ".")
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("go", args...)
+	cmd.Dir = spec.buildPkg.Dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	log.Debug("running examples")
+	runErr := cmd.Run()
+
+	// The import path of the package is reported by go test, since the build
+	// package only knows the path of local packages relative to their
+	// directory.
+	var (
+		importPath  = spec.ImportPath
+		failed      []string
+		buildOutput strings.Builder
+		testOutput  = make(map[string]*strings.Builder)
+	)
+
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		var ev testEvent
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			// Depending on the version of go, build errors may be printed
+			// without being wrapped in an event.
+			buildOutput.WriteString(scanner.Text() + "\n")
+			continue
+		}
+
+		if ev.Package != "" {
+			importPath = ev.Package
+		}
+
+		switch {
+		case ev.Action == "build-output":
+			buildOutput.WriteString(ev.Output)
+		case ev.Action == "output" && ev.Test != "":
+			if testOutput[ev.Test] == nil {
+				testOutput[ev.Test] = &strings.Builder{}
+			}
+
+			testOutput[ev.Test].WriteString(ev.Output)
+		case ev.Action == "fail" && strings.HasPrefix(ev.Test, "Example"):
+			failed = append(failed, ev.Test)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("gomarkdoc: failed to read the results of the examples of package %s: %w", importPath, err)
+	}
+
+	if len(failed) == 0 {
+		if runErr == nil {
+			return nil
+		}
+
+		buildOutput.Write(stderr.Bytes())
+		return fmt.Errorf(
+			"gomarkdoc: examples of package %s failed to build: %w\n%s",
+			importPath,
+			runErr,
+			strings.TrimSpace(buildOutput.String()),
+		)
+	}
+
+	var errs multiError
+	for _, name := range failed {
+		var details []string
+		if out := testOutput[name]; out != nil {
+			for _, line := range strings.Split(out.String(), "\n") {
+				// The progress lines of go test only repeat the name of the
+				// example.
+				if strings.HasPrefix(line, "=== ") || strings.HasPrefix(line, "--- ") {
+					continue
+				}
+
+				details = append(details, "    "+line)
+			}
+		}
+
+		errs = append(errs, fmt.Errorf(
+			"gomarkdoc: example %s of package %s failed:\n%s",
+			name,
+			importPath,
+			strings.TrimRight(strings.Join(details, "\n"), " \n"),
+		))
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+
+	return errs
+}
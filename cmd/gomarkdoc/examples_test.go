@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestVerifyExamples(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		is.NoErr(os.MkdirAll(filepath.Dir(path), 0755))
+		is.NoErr(os.WriteFile(path, []byte(content), 0664))
+	}
+
+	write("go.mod", "module example.com/examples\n\ngo 1.19\n")
+	write("valid/valid.go", "// Package valid is valid.\npackage valid\n\n// Greet greets.\nfunc Greet() string { return \"hello\" }\n")
+	write("valid/example_test.go", "package valid_test\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/examples/valid\"\n)\n\n"+
+		"func ExampleGreet() {\n\tfmt.Println(valid.Greet())\n\t// Output: hello\n}\n")
+	write("stale/stale.go", "// Package stale is stale.\npackage stale\n\n// Greet greets.\nfunc Greet() string { return \"hello\" }\n")
+	write("stale/example_test.go", "package stale_test\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/examples/stale\"\n)\n\n"+
+		"func ExampleGreet() {\n\tfmt.Println(stale.Greet())\n\t// Output: hallo\n}\n")
+	write("broken/broken.go", "// Package broken is broken.\npackage broken\n")
+	write("broken/example_test.go", "package broken_test\n\nfunc ExampleMissing() {\n\tmissing()\n\t// Output:\n}\n")
+	write("untested/untested.go", "// Package untested has no tests.\npackage untested\n")
+
+	is.NoErr(os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	verify := func(path string) error {
+		specs := getSpecs(path)
+		is.NoErr(resolveBuildPackages(specs, commandOptions{}))
+		return verifyExamples(specs, commandOptions{})
+	}
+
+	is.NoErr(verify("./valid"))
+	is.NoErr(verify("./untested"))
+
+	err := verify("./stale")
+	is.True(err != nil)
+	is.True(strings.HasPrefix(err.Error(), "gomarkdoc: example ExampleGreet of package example.com/examples/stale failed:\n"))
+	is.True(strings.Contains(err.Error(), "    got:\n    hello\n    want:\n    hallo"))
+
+	err = verify("./broken")
+	is.True(err != nil)
+	is.True(strings.HasPrefix(err.Error(), "gomarkdoc: examples of package example.com/examples/broken failed to build"))
+	is.True(strings.Contains(err.Error(), "undefined: missing"))
+}
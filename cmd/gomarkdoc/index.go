@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cloudogu/gomarkdoc"
+	"github.com/cloudogu/gomarkdoc/lang"
+)
+
+// checkIndexOutput ensures that the index can link to the output files of the
+// packages. It must be written to a file of its own.
+func checkIndexOutput(specs []*PackageSpec, opts commandOptions) error {
+	index := filepath.Clean(opts.indexOutput)
+	for _, spec := range specs {
+		if spec.outputFile == "" {
+			return fmt.Errorf("gomarkdoc: the index requires the documentation of package %s to be written to a file", spec.ImportPath)
+		}
+
+		if spec.outputFile == index {
+			return fmt.Errorf("gomarkdoc: the index output %s is also the output of package %s", opts.indexOutput, spec.ImportPath)
+		}
+	}
+
+	return nil
+}
+
+// writeIndex writes the index of all documented packages to the index output,
+// linking each package to its output file relative to the index.
+func writeIndex(specs []*PackageSpec, opts commandOptions, out *gomarkdoc.Renderer, cache *outputCache) error {
+	header, err := resolveHeader(opts)
+	if err != nil {
+		return err
+	}
+
+	footer, err := resolveFooter(opts)
+	if err != nil {
+		return err
+	}
+
+	fileName := filepath.Clean(opts.indexOutput)
+
+	var entries []*lang.ModIndexEntry
+	for _, spec := range specs {
+		if spec.pkg == nil {
+			continue
+		}
+
+		href, err := filepath.Rel(filepath.Dir(fileName), spec.outputFile)
+		if err != nil {
+			return fmt.Errorf("gomarkdoc: unable to link package %s from the index: %w", spec.ImportPath, err)
+		}
+
+		entries = append(entries, &lang.ModIndexEntry{Package: spec.pkg, Href: filepath.ToSlash(href)})
+	}
+
+	text, err := out.ModIndex(lang.NewModIndex(header, footer, entries))
+	if err != nil {
+		return err
+	}
+
+	if opts.check {
+		var b bytes.Buffer
+		fmt.Fprint(&b, text)
+		return checkFile(&b, fileName)
+	}
+
+	if err := writeFile(fileName, text); err != nil {
+		return fmt.Errorf("failed to write index file %s: %w", fileName, err)
+	}
+
+	if cache != nil {
+		cache.store(fileName, text)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/viper"
+)
+
+func TestCommand_index(t *testing.T) {
+	is := is.New(t)
+	t.Cleanup(viper.Reset)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	dir := t.TempDir()
+	index := filepath.Join(dir, "docs", "README.md")
+	args := []string{
+		"--no-cache",
+		"--repository.url", "https://github.com/cloudogu/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+		"--output", filepath.Join(dir, "{{.ImportPath}}", "README.md"),
+		"--index-output", index,
+		"./simple", "./nested",
+	}
+
+	cmd := buildCommand()
+	cmd.SetArgs(args)
+	is.NoErr(cmd.Execute())
+
+	b, err := os.ReadFile(index)
+	is.NoErr(err)
+
+	text := string(b)
+	is.True(strings.Contains(text, "# Packages"))
+	is.True(strings.Contains(text, "## [github.com/cloudogu/gomarkdoc/testData/simple](<../simple/README.md>)"))
+	is.True(strings.Contains(text, "## [github.com/cloudogu/gomarkdoc/testData/nested](<../nested/README.md>)"))
+	is.True(strings.Index(text, "testData/simple") < strings.Index(text, "testData/nested")) // packages are listed in order
+
+	cmd = buildCommand()
+	cmd.SetArgs(append(args, "--check"))
+	is.NoErr(cmd.Execute())
+
+	is.NoErr(os.WriteFile(index, []byte("outdated"), 0664))
+	cmd = buildCommand()
+	cmd.SetArgs(append(args, "--check"))
+	is.True(cmd.Execute() != nil)
+}
+
+func TestCommand_indexInvalid(t *testing.T) {
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	output := filepath.Join(dir, "README.md")
+
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"no output": {
+			[]string{"--index-output", output, "./simple"},
+			"gomarkdoc: index output cannot be written without an output set",
+		},
+		"same file": {
+			[]string{"--no-cache", "--output", output, "--index-output", output, "./simple"},
+			"gomarkdoc: the index output " + output + " is also the output of package ./simple",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			t.Cleanup(viper.Reset)
+
+			cmd := buildCommand()
+			cmd.SetArgs(test.args)
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			err := cmd.Execute()
+			is.True(err != nil)
+			is.Equal(err.Error(), test.err)
+		})
+	}
+}
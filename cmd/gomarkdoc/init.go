@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/cloudogu/gomarkdoc"
+)
+
+// defaultTemplateDir is the directory to which the init command writes the
+// default templates when requested.
+var defaultTemplateDir = filepath.Join(configFilePrefix, "templates")
+
+type initOptions struct {
+	configFile string
+	templates  bool
+	force      bool
+}
+
+func buildInitCommand(root *cobra.Command) *cobra.Command {
+	var opts initOptions
+
+	var command = &cobra.Command{
+		Use:   "init",
+		Short: "create a configuration file listing all available options",
+		Long: fmt.Sprintf(
+			"Create a commented %s.yml listing all available options. With --templates, the default templates "+
+				"are written to %s and referenced from the configuration so they can be customized.",
+			configFilePrefix,
+			filepath.ToSlash(defaultTemplateDir),
+		),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInitCommand(root, opts)
+		},
+	}
+
+	flags := command.Flags()
+	flags.StringVar(
+		&opts.configFile,
+		"config",
+		configFilePrefix+".yml",
+		"File to which the configuration is written.",
+	)
+	flags.BoolVar(
+		&opts.templates,
+		"templates",
+		false,
+		fmt.Sprintf(
+			"Write the templates of the selected --profile to %s and use them as template file overrides.",
+			filepath.ToSlash(defaultTemplateDir),
+		),
+	)
+	flags.BoolVar(
+		&opts.force,
+		"force",
+		false,
+		"Overwrite files that already exist.",
+	)
+
+	return command
+}
+
+func runInitCommand(root *cobra.Command, opts initOptions) error {
+	profile := lookupFlag(root, "profile").Value.String()
+
+	var templateFiles map[string]string
+	if opts.templates {
+		var err error
+		templateFiles, err = writeDefaultTemplates(defaultTemplateDir, profile, opts.force)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := checkOverwrite(opts.configFile, opts.force); err != nil {
+		return err
+	}
+
+	return writeFile(opts.configFile, buildInitConfig(root, profile, templateFiles))
+}
+
+// writeDefaultTemplates writes every built-in template of the profile to its
+// own file in the provided directory. It returns the written files keyed by
+// template name.
+func writeDefaultTemplates(dir, profile string, force bool) (map[string]string, error) {
+	tmpls, err := gomarkdoc.ProfileTemplates(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string)
+	for name := range tmpls {
+		files[name] = filepath.ToSlash(filepath.Join(dir, name+".gotxt"))
+	}
+
+	for _, f := range files {
+		if err := checkOverwrite(f, force); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, tmpl := range tmpls {
+		if err := writeFile(files[name], tmpl); err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to write template %s: %w", name, err)
+		}
+	}
+
+	return files, nil
+}
+
+func checkOverwrite(fileName string, force bool) error {
+	if force {
+		return nil
+	}
+
+	if _, err := os.Stat(fileName); err == nil {
+		return fmt.Errorf("gomarkdoc: %s already exists. Use --force to overwrite it", fileName)
+	}
+
+	return nil
+}
+
+// buildInitConfig generates the contents of a configuration file describing
+// every option in configBindings. All options are commented out with their
+// default value, except for the template files provided and the profile. The
+// profile is set if it isn't the default or if template files are provided,
+// since those are the templates of the profile.
+func buildInitConfig(root *cobra.Command, profile string, templateFiles map[string]string) string {
+	var b strings.Builder
+
+	b.WriteString("# Configuration for gomarkdoc (https://github.com/cloudogu/gomarkdoc).\n")
+	b.WriteString("#\n")
+	b.WriteString("# Every option may also be provided on the command line, which takes\n")
+	b.WriteString("# precedence over the values in this file. Uncomment an option to change it.\n")
+
+	var section string
+	for _, binding := range configBindings {
+		flag := lookupFlag(root, binding.flag)
+		if flag == nil {
+			continue
+		}
+
+		key := binding.key
+		if idx := strings.Index(key, "."); idx != -1 {
+			if key[:idx] != section {
+				section = key[:idx]
+				fmt.Fprintf(&b, "\n# %s:\n", section)
+			}
+
+			// Nested options are commented out together with their section.
+			fmt.Fprintf(&b, "#   # %s\n", flag.Usage)
+			fmt.Fprintf(&b, "#   %s: %s\n", key[idx+1:], yamlDefault(flag))
+			continue
+		}
+
+		section = ""
+		fmt.Fprintf(&b, "\n# %s\n", flag.Usage)
+
+		if key == "profile" && (profile != flag.DefValue || len(templateFiles) > 0) {
+			fmt.Fprintf(&b, "%s: %s\n", key, strconv.Quote(profile))
+			continue
+		}
+
+		if key == "templateFile" && len(templateFiles) > 0 {
+			fmt.Fprintf(&b, "%s:\n", key)
+			for _, name := range sortedKeys(templateFiles) {
+				fmt.Fprintf(&b, "  %s: %s\n", name, templateFiles[name])
+			}
+
+			continue
+		}
+
+		fmt.Fprintf(&b, "# %s: %s\n", key, yamlDefault(flag))
+	}
+
+	b.WriteString("\n# Named targets, each generating documentation for its own packages. Running\n")
+	b.WriteString("# gomarkdoc without packages runs all targets, --target selects specific ones.\n")
+	b.WriteString("# Options not set for a target are inherited from the options above.\n")
+	b.WriteString("# targets:\n")
+	b.WriteString("#   - name: docs\n")
+	b.WriteString("#     packages: [\"./...\"]\n")
+	b.WriteString("#     output: \"{{.Dir}}/README.md\"\n")
+
+	return b.String()
+}
+
+// yamlDefault renders the default value of a flag as a YAML value.
+func yamlDefault(flag *pflag.Flag) string {
+	switch flag.Value.Type() {
+	case "string":
+		return strconv.Quote(flag.DefValue)
+	case "stringSlice":
+		var values []string
+		if flag.DefValue != "[]" {
+			values = strings.Split(strings.Trim(flag.DefValue, "[]"), ",")
+		}
+
+		quoted := make([]string, len(values))
+		for i, v := range values {
+			quoted[i] = strconv.Quote(v)
+		}
+
+		return fmt.Sprintf("[%s]", strings.Join(quoted, ", "))
+	case "stringToString":
+		return "{}"
+	default:
+		return flag.DefValue
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/viper"
+
+	"github.com/cloudogu/gomarkdoc"
+)
+
+func TestBuildInitConfig(t *testing.T) {
+	is := is.New(t)
+
+	cmd := buildCommand()
+	config := buildInitConfig(cmd, gomarkdoc.ProfileStructs, nil)
+
+	for _, binding := range configBindings {
+		key := binding.key
+		if idx := strings.Index(key, "."); idx != -1 {
+			is.True(strings.Contains(config, "# "+key[:idx]+":\n"))
+			key = key[idx+1:]
+		}
+
+		is.True(strings.Contains(config, key+": ")) // every option is listed
+	}
+
+	// The generated file is valid configuration without any values set
+	v := viper.New()
+	v.SetConfigType("yaml")
+	is.NoErr(v.ReadConfig(strings.NewReader(config)))
+	is.Equal(len(v.AllKeys()), 0)
+}
+
+func TestInitCommand_templates(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(t.TempDir())
+	is.NoErr(err)
+	defer func() { _ = os.Chdir(wd) }()
+
+	os.Args = []string{"gomarkdoc", "init", "--templates"}
+	cmd := buildCommand()
+	is.NoErr(cmd.Execute())
+
+	v := viper.New()
+	v.SetConfigFile(configFilePrefix + ".yml")
+	is.NoErr(v.ReadInConfig())
+
+	is.Equal(v.GetString("profile"), gomarkdoc.ProfileStructs) // the templates belong to the profile
+
+	templateFiles := v.GetStringMapString("templateFile")
+	is.Equal(len(templateFiles), len(gomarkdoc.DefaultTemplates()))
+
+	for name, tmpl := range gomarkdoc.DefaultTemplates() {
+		b, err := os.ReadFile(templateFiles[name])
+		is.NoErr(err)
+		is.Equal(string(b), tmpl)
+		is.Equal(templateFiles[name], filepath.ToSlash(filepath.Join(".gomarkdoc", "templates", name+".gotxt")))
+	}
+
+	// Existing files are not overwritten without --force
+	cmd = buildCommand()
+	cmd.SetArgs([]string{"init"})
+	err = cmd.Execute()
+	is.True(err != nil)
+	is.Equal(err.Error(), "gomarkdoc: .gomarkdoc.yml already exists. Use --force to overwrite it")
+
+	cmd = buildCommand()
+	cmd.SetArgs([]string{"init", "--force"})
+	is.NoErr(cmd.Execute())
+}
+
+func TestInitCommand_profile(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(t.TempDir())
+	is.NoErr(err)
+	defer func() { _ = os.Chdir(wd) }()
+
+	cmd := buildCommand()
+	cmd.SetArgs([]string{"init", "--templates", "--profile", gomarkdoc.ProfileFull})
+	is.NoErr(cmd.Execute())
+
+	full, err := gomarkdoc.ProfileTemplates(gomarkdoc.ProfileFull)
+	is.NoErr(err)
+
+	b, err := os.ReadFile(filepath.Join(".gomarkdoc", "templates", "package.gotxt"))
+	is.NoErr(err)
+	is.Equal(string(b), full["package"]) // templates of the selected profile are written
+
+	v := viper.New()
+	v.SetConfigFile(configFilePrefix + ".yml")
+	is.NoErr(v.ReadInConfig())
+	is.Equal(v.GetString("profile"), gomarkdoc.ProfileFull)
+}
+
+func TestBuildInitConfig_profile(t *testing.T) {
+	is := is.New(t)
+
+	cmd := buildCommand()
+	config := buildInitConfig(cmd, gomarkdoc.ProfileFull, nil)
+	is.True(strings.Contains(config, "\nprofile: \"full\"\n")) // a profile other than the default is set
+
+	config = buildInitConfig(cmd, gomarkdoc.ProfileStructs, nil)
+	is.True(strings.Contains(config, "\n# profile: \"structs\"\n"))
+}
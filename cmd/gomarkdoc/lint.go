@@ -0,0 +1,412 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudogu/gomarkdoc/lang"
+)
+
+// lintLevel is the severity of a lint problem. The names match the levels of
+// SARIF results.
+type lintLevel string
+
+const (
+	lintError   lintLevel = "error"
+	lintWarning lintLevel = "warning"
+	lintNote    lintLevel = "note"
+	lintNone    lintLevel = "none"
+)
+
+// lintLevelRanks orders the levels by severity.
+var lintLevelRanks = map[lintLevel]int{
+	lintNone:    0,
+	lintNote:    1,
+	lintWarning: 2,
+	lintError:   3,
+}
+
+// lintRuleInfo describes a lint rule for the reports.
+type lintRuleInfo struct {
+	level       lintLevel
+	description string
+}
+
+var lintRules = map[lang.LintRule]lintRuleInfo{
+	lang.MissingDocRule:      {lintWarning, "Exported packages, types and funcs should be documented."},
+	lang.MissingFieldDocRule: {lintWarning, "Exported struct fields should be documented."},
+	lang.DocPrefixRule:       {lintNote, "Documentation should start with the name of the symbol."},
+	lang.BrokenDocLinkRule:   {lintError, "Doc links should refer to existing symbols, packages or link definitions."},
+}
+
+// lintOptions holds the options of the lint command.
+type lintOptions struct {
+	reportFormat string
+	output       string
+	failOn       string
+	minCoverage  float64
+}
+
+func buildLintCommand(configFile *string) *cobra.Command {
+	var lintOpts lintOptions
+
+	var command = &cobra.Command{
+		Use:   "lint [package ...]",
+		Short: "report missing or broken documentation and the documentation coverage",
+		Long: "Report exported packages, types, funcs and struct fields without documentation, documentation " +
+			"which doesn't start with the name of the symbol and broken doc links, along with the share of " +
+			"documented symbols. The command fails if a problem at or above the --fail-on level is found or " +
+			"the coverage is below --min-coverage.",
+		Args: cobra.ArbitraryArgs,
+		// Problems are reported in detail, so the usage doesn't need to be
+		// printed.
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			buildConfig(*configFile)
+
+			var opts commandOptions
+			loadOptions(&opts)
+
+			if len(args) == 0 {
+				// Default to current directory
+				args = []string{"."}
+			}
+
+			if lintOpts.output == "" {
+				return runLint(cmd.OutOrStdout(), args, opts, lintOpts)
+			}
+
+			f, err := os.Create(lintOpts.output)
+			if err != nil {
+				return fmt.Errorf("gomarkdoc: failed to create report file %s: %w", lintOpts.output, err)
+			}
+			defer f.Close()
+
+			return runLint(f, args, opts, lintOpts)
+		},
+	}
+
+	flags := command.Flags()
+	flags.StringVar(
+		&lintOpts.reportFormat,
+		"report-format",
+		"human",
+		"Format of the report. Valid options: human, json, sarif",
+	)
+	flags.StringVarP(
+		&lintOpts.output,
+		"output",
+		"o",
+		"",
+		"File to which the report is written. Defaults to printing to stdout.",
+	)
+	flags.StringVar(
+		&lintOpts.failOn,
+		"fail-on",
+		string(lintWarning),
+		"Lowest level of the problems which fail the command. Valid options: error, warning, note, none",
+	)
+	flags.Float64Var(
+		&lintOpts.minCoverage,
+		"min-coverage",
+		0,
+		"Minimum percentage of documented symbols, below which the command fails.",
+	)
+
+	return command
+}
+
+// runLint checks the documentation of the packages at the provided paths and
+// writes the report to w. An error is returned if the thresholds of the
+// options are exceeded.
+func runLint(w io.Writer, paths []string, opts commandOptions, lintOpts lintOptions) error {
+	failOn := lintLevel(lintOpts.failOn)
+	if _, ok := lintLevelRanks[failOn]; !ok {
+		return fmt.Errorf("gomarkdoc: invalid level %q. Valid options: error, warning, note, none", lintOpts.failOn)
+	}
+
+	var write func(io.Writer, []*lang.LintResult) error
+	switch lintOpts.reportFormat {
+	case "human":
+		write = writeHumanLintReport
+	case "json":
+		write = writeJSONLintReport
+	case "sarif":
+		write = writeSARIFLintReport
+	default:
+		return fmt.Errorf("gomarkdoc: invalid report format %q. Valid options: human, json, sarif", lintOpts.reportFormat)
+	}
+
+	specs := getSpecs(paths...)
+	if err := resolveBuildPackages(specs, opts); err != nil {
+		return err
+	}
+
+	resolvePinnedRef(specs, &opts)
+
+	if err := loadPackages(specs, opts, &sharedPackages{pkgs: make(map[string]*lang.Package)}); err != nil {
+		return err
+	}
+
+	var results []*lang.LintResult
+	for _, spec := range specs {
+		if spec.pkg != nil {
+			results = append(results, lang.LintPackage(spec.pkg))
+		}
+	}
+
+	if err := write(w, results); err != nil {
+		return err
+	}
+
+	var failed int
+	for _, r := range results {
+		for _, p := range r.Problems {
+			if failOn != lintNone && lintLevelRanks[lintRules[p.Rule].level] >= lintLevelRanks[failOn] {
+				failed++
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("gomarkdoc: found %d documentation problem(s) at or above level %s", failed, failOn)
+	}
+
+	if coverage := totalCoverage(results); coverage < lintOpts.minCoverage {
+		return fmt.Errorf("gomarkdoc: documentation coverage of %.1f%% is below the minimum of %.1f%%", coverage, lintOpts.minCoverage)
+	}
+
+	return nil
+}
+
+// totalCoverage provides the percentage of documented symbols across all of
+// the packages.
+func totalCoverage(results []*lang.LintResult) float64 {
+	total := &lang.LintResult{}
+	for _, r := range results {
+		total.Symbols += r.Symbols
+		total.Documented += r.Documented
+	}
+
+	return total.Coverage()
+}
+
+// lintProblemFile provides the path of the file of the problem relative to the
+// current directory where possible.
+func lintProblemFile(p *lang.LintProblem) string {
+	path := p.Location.Filepath
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+
+	return filepath.ToSlash(path)
+}
+
+func writeHumanLintReport(w io.Writer, results []*lang.LintResult) error {
+	var problems int
+	for _, r := range results {
+		for _, p := range r.Problems {
+			problems++
+			fmt.Fprintf(
+				w,
+				"%s:%d:%d: %s: %s (%s)\n",
+				lintProblemFile(p),
+				p.Location.Start.Line,
+				p.Location.Start.Col,
+				lintRules[p.Rule].level,
+				p.Message,
+				p.Rule,
+			)
+		}
+	}
+
+	if problems > 0 {
+		fmt.Fprintln(w)
+	}
+
+	for _, r := range results {
+		fmt.Fprintf(w, "%s: %.1f%% documented (%d of %d symbols)\n", r.ImportPath, r.Coverage(), r.Documented, r.Symbols)
+	}
+
+	_, err := fmt.Fprintf(w, "total: %.1f%% documented, %d problem(s)\n", totalCoverage(results), problems)
+	return err
+}
+
+type (
+	jsonLintReport struct {
+		Coverage float64           `json:"coverage"`
+		Packages []jsonLintPackage `json:"packages"`
+	}
+
+	jsonLintPackage struct {
+		ImportPath string            `json:"importPath"`
+		Coverage   float64           `json:"coverage"`
+		Symbols    int               `json:"symbols"`
+		Documented int               `json:"documented"`
+		Problems   []jsonLintProblem `json:"problems"`
+	}
+
+	jsonLintProblem struct {
+		Rule    lang.LintRule `json:"rule"`
+		Level   lintLevel     `json:"level"`
+		Symbol  string        `json:"symbol"`
+		Message string        `json:"message"`
+		File    string        `json:"file"`
+		Line    int           `json:"line"`
+		Column  int           `json:"column"`
+	}
+)
+
+func writeJSONLintReport(w io.Writer, results []*lang.LintResult) error {
+	report := jsonLintReport{
+		Coverage: totalCoverage(results),
+		Packages: make([]jsonLintPackage, 0, len(results)),
+	}
+
+	for _, r := range results {
+		pkg := jsonLintPackage{
+			ImportPath: r.ImportPath,
+			Coverage:   r.Coverage(),
+			Symbols:    r.Symbols,
+			Documented: r.Documented,
+			Problems:   make([]jsonLintProblem, 0, len(r.Problems)),
+		}
+
+		for _, p := range r.Problems {
+			pkg.Problems = append(pkg.Problems, jsonLintProblem{
+				Rule:    p.Rule,
+				Level:   lintRules[p.Rule].level,
+				Symbol:  p.Symbol,
+				Message: p.Message,
+				File:    lintProblemFile(p),
+				Line:    p.Location.Start.Line,
+				Column:  p.Location.Start.Col,
+			})
+		}
+
+		report.Packages = append(report.Packages, pkg)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// The SARIF report follows version 2.1.0 of the Static Analysis Results
+// Interchange Format, which is understood by code scanning tools.
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Version        string      `json:"version,omitempty"`
+		Rules          []sarifRule `json:"rules"`
+	}
+
+	sarifRule struct {
+		ID                   string             `json:"id"`
+		ShortDescription     sarifMessage       `json:"shortDescription"`
+		DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	}
+
+	sarifConfiguration struct {
+		Level lintLevel `json:"level"`
+	}
+
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     lintLevel       `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           sarifRegion           `json:"region"`
+	}
+
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn"`
+	}
+)
+
+func writeSARIFLintReport(w io.Writer, results []*lang.LintResult) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "gomarkdoc",
+			InformationURI: "https://github.com/cloudogu/gomarkdoc",
+			Version:        getVersion(),
+		}},
+		Results: []sarifResult{},
+	}
+
+	for _, rule := range lang.LintRules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:                   string(rule),
+			ShortDescription:     sarifMessage{lintRules[rule].description},
+			DefaultConfiguration: sarifConfiguration{lintRules[rule].level},
+		})
+	}
+
+	for _, r := range results {
+		for _, p := range r.Problems {
+			run.Results = append(run.Results, sarifResult{
+				RuleID:  string(p.Rule),
+				Level:   lintRules[p.Rule].level,
+				Message: sarifMessage{p.Message},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: lintProblemFile(p)},
+						Region: sarifRegion{
+							StartLine:   p.Location.Start.Line,
+							StartColumn: p.Location.Start.Col,
+						},
+					},
+				}},
+			})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
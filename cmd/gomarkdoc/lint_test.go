@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestRunLint(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	var buf bytes.Buffer
+	err = runLint(&buf, []string{"./lang/lint"}, commandOptions{}, lintOptions{reportFormat: "human", failOn: "error"})
+	is.Equal(err.Error(), "gomarkdoc: found 2 documentation problem(s) at or above level error")
+
+	out := buf.String()
+	is.True(strings.Contains(out, "lang/lint/lint.go:12:2: warning: field Config.Value has no documentation (missing-field-doc)\n"))
+	is.True(strings.HasSuffix(out, "github.com/cloudogu/gomarkdoc/testData/lang/lint: 66.7% documented (6 of 9 symbols)\n"+
+		"total: 66.7% documented, 6 problem(s)\n"))
+
+	buf.Reset()
+	err = runLint(&buf, []string{"./lang/lint"}, commandOptions{}, lintOptions{reportFormat: "json", failOn: "none", minCoverage: 50})
+	is.NoErr(err)
+
+	var report jsonLintReport
+	is.NoErr(json.Unmarshal(buf.Bytes(), &report))
+	is.Equal(len(report.Packages), 1)
+	is.Equal(len(report.Packages[0].Problems), 6)
+	is.Equal(report.Packages[0].Problems[1], jsonLintProblem{
+		Rule:    "missing-doc",
+		Level:   lintWarning,
+		Symbol:  "Run",
+		Message: "Run has no documentation",
+		File:    "lang/lint/lint.go",
+		Line:    28,
+		Column:  1,
+	})
+
+	buf.Reset()
+	err = runLint(&buf, []string{"./lang/lint"}, commandOptions{}, lintOptions{reportFormat: "sarif", failOn: "none", minCoverage: 70})
+	is.Equal(err.Error(), "gomarkdoc: documentation coverage of 66.7% is below the minimum of 70.0%")
+
+	var log sarifLog
+	is.NoErr(json.Unmarshal(buf.Bytes(), &log))
+	is.Equal(log.Version, "2.1.0")
+	is.Equal(len(log.Runs[0].Tool.Driver.Rules), 4)
+	is.Equal(len(log.Runs[0].Results), 6)
+	is.Equal(log.Runs[0].Results[0].RuleID, "broken-doc-link")
+	is.Equal(log.Runs[0].Results[0].Level, lintError)
+	is.Equal(log.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI, "lang/lint/lint.go")
+
+	err = runLint(&buf, nil, commandOptions{}, lintOptions{reportFormat: "xml", failOn: "none"})
+	is.Equal(err.Error(), `gomarkdoc: invalid report format "xml". Valid options: human, json, sarif`)
+
+	err = runLint(&buf, nil, commandOptions{}, lintOptions{reportFormat: "human", failOn: "fatal"})
+	is.Equal(err.Error(), `gomarkdoc: invalid level "fatal". Valid options: error, warning, note, none`)
+}
@@ -7,26 +7,15 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"regexp"
 
 	"github.com/cloudogu/gomarkdoc"
 	"github.com/cloudogu/gomarkdoc/lang"
 	"github.com/cloudogu/gomarkdoc/logger"
 )
 
-func writeOutput(specs []*PackageSpec, opts commandOptions) error {
+func writeOutput(specs []*PackageSpec, opts commandOptions, out *gomarkdoc.Renderer, cache *outputCache, shared *sharedPackages) error {
 	log := logger.New(getLogLevel(opts.verbosity))
 
-	overrides, err := resolveOverrides(opts)
-	if err != nil {
-		return err
-	}
-
-	out, err := gomarkdoc.NewRenderer(overrides...)
-	if err != nil {
-		return err
-	}
-
 	header, err := resolveHeader(opts)
 	if err != nil {
 		return err
@@ -37,28 +26,82 @@ func writeOutput(specs []*PackageSpec, opts commandOptions) error {
 		return err
 	}
 
+	// Group packages by output file, keeping the files in the order in which
+	// they are first referenced so that output is deterministic.
+	var fileNames []string
 	filePkgs := make(map[string][]*lang.Package)
 
 	for _, spec := range specs {
-		if spec.pkg == nil {
+		// Packages of cached specs may have been loaded for the index only.
+		if spec.pkg == nil || spec.cached {
 			continue
 		}
 
+		if _, ok := filePkgs[spec.outputFile]; !ok {
+			fileNames = append(fileNames, spec.outputFile)
+		}
+
 		filePkgs[spec.outputFile] = append(filePkgs[spec.outputFile], spec.pkg)
 	}
 
-	for fileName, pkgs := range filePkgs {
-		file := lang.NewFile(header, footer, pkgs)
+	renderFile := func(fileName string) (string, error) {
+		return out.File(lang.NewFile(header, footer, filePkgs[fileName]))
+	}
 
-		text, err := out.File(file)
+	var regions *regionRenderer
+	if opts.embed {
+		catalog, err := loadCatalog(opts)
 		if err != nil {
 			return err
 		}
 
+		order, err := lang.ParseOrder(opts.order)
+		if err != nil {
+			return err
+		}
+
+		regions = &regionRenderer{
+			out:      out,
+			opts:     opts,
+			catalog:  catalog,
+			order:    order,
+			shared:   shared,
+			fullText: renderFile,
+			filePkgs: filePkgs,
+		}
+	}
+
+	texts := make([]string, len(fileNames))
+	err = runParallel(opts.jobs, len(fileNames), func(i int) error {
+		fileName := fileNames[i]
+
 		if opts.embed && fileName != "" {
-			text = embedContents(log, fileName, text)
+			text, err := embedContents(log, fileName, func(attrs embedAttrs) (string, error) {
+				return regions.render(fileName, attrs)
+			})
+			if err != nil {
+				return err
+			}
+
+			texts[i] = text
+			return nil
+		}
+
+		text, err := renderFile(fileName)
+		if err != nil {
+			return err
 		}
 
+		texts[i] = text
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, fileName := range fileNames {
+		text := texts[i]
+
 		switch {
 		case fileName == "":
 			fmt.Fprint(os.Stdout, text)
@@ -72,6 +115,10 @@ func writeOutput(specs []*PackageSpec, opts commandOptions) error {
 			if err := writeFile(fileName, text); err != nil {
 				return fmt.Errorf("failed to write output file %s: %w", fileName, err)
 			}
+
+			if cache != nil {
+				cache.store(fileName, text)
+			}
 		}
 	}
 
@@ -79,6 +126,12 @@ func writeOutput(specs []*PackageSpec, opts commandOptions) error {
 }
 
 func writeFile(fileName string, text string) error {
+	// Leave files with identical contents untouched to keep their modification
+	// times stable.
+	if existing, err := ioutil.ReadFile(fileName); err == nil && string(existing) == text {
+		return nil
+	}
+
 	folder := filepath.Dir(fileName)
 
 	if folder != "" {
@@ -119,38 +172,3 @@ func checkFile(b *bytes.Buffer, path string) error {
 
 	return nil
 }
-
-var (
-	embedStandaloneRegex = regexp.MustCompile(`(?m:^ *)<!--\s*gomarkdoc:embed\s*-->(?m:\s*?$)`)
-	embedStartRegex      = regexp.MustCompile(
-		`(?m:^ *)<!--\s*gomarkdoc:embed:start\s*-->(?s:.*?)<!--\s*gomarkdoc:embed:end\s*-->(?m:\s*?$)`,
-	)
-)
-
-func embedContents(log logger.Logger, fileName string, text string) string {
-	embedText := fmt.Sprintf("<!-- gomarkdoc:embed:start -->\n\n%s\n\n<!-- gomarkdoc:embed:end -->", text)
-
-	data, err := os.ReadFile(fileName)
-	if err != nil {
-		log.Debugf("unable to find output file %s for embedding. Creating a new file instead", fileName)
-		return embedText
-	}
-
-	var replacements int
-	data = embedStandaloneRegex.ReplaceAllFunc(data, func(_ []byte) []byte {
-		replacements++
-		return []byte(embedText)
-	})
-
-	data = embedStartRegex.ReplaceAllFunc(data, func(_ []byte) []byte {
-		replacements++
-		return []byte(embedText)
-	})
-
-	if replacements == 0 {
-		log.Debugf("no embed markers found. Appending documentation to the end of the file instead")
-		return fmt.Sprintf("%s\n\n%s", string(data), text)
-	}
-
-	return string(data)
-}
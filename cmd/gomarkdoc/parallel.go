@@ -0,0 +1,88 @@
+package main
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// multiError aggregates the errors produced by independent units of work. The
+// errors are kept in the order of the work items that produced them so that
+// reporting is deterministic regardless of scheduling.
+type multiError []error
+
+// Error joins the messages of all contained errors, one per line.
+func (m multiError) Error() string {
+	msgs := make([]string, len(m))
+	for i, err := range m {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap exposes the contained errors to errors.Is and errors.As.
+func (m multiError) Unwrap() []error {
+	return m
+}
+
+// resolveJobs normalizes the requested number of concurrent jobs. Any value
+// less than 1 results in one job per available CPU.
+func resolveJobs(jobs int) int {
+	if jobs < 1 {
+		return runtime.NumCPU()
+	}
+
+	return jobs
+}
+
+// runParallel calls fn for every index in [0, n) using at most jobs
+// concurrent workers. All work items are attempted, even if some of them
+// fail. If exactly one item fails, its error is returned as-is. If several
+// fail, a multiError holding the errors in index order is returned.
+func runParallel(jobs, n int, fn func(i int) error) error {
+	if n == 0 {
+		return nil
+	}
+
+	jobs = resolveJobs(jobs)
+	if jobs > n {
+		jobs = n
+	}
+
+	errs := make([]error, n)
+	work := make(chan int)
+
+	var wg sync.WaitGroup
+	wg.Add(jobs)
+	for w := 0; w < jobs; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				errs[i] = fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+
+	var failed multiError
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+
+	switch len(failed) {
+	case 0:
+		return nil
+	case 1:
+		return failed[0]
+	default:
+		return failed
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestRunParallel(t *testing.T) {
+	is := is.New(t)
+
+	var calls int32
+	results := make([]int, 20)
+	err := runParallel(3, len(results), func(i int) error {
+		atomic.AddInt32(&calls, 1)
+		results[i] = i * 2
+		return nil
+	})
+	is.NoErr(err)
+
+	is.Equal(int(calls), len(results))
+	for i, r := range results {
+		is.Equal(r, i*2)
+	}
+}
+
+func TestRunParallel_singleError(t *testing.T) {
+	is := is.New(t)
+
+	errFailed := errors.New("failed")
+	err := runParallel(4, 10, func(i int) error {
+		if i == 5 {
+			return errFailed
+		}
+
+		return nil
+	})
+
+	is.Equal(err, errFailed)
+}
+
+func TestRunParallel_multipleErrors(t *testing.T) {
+	is := is.New(t)
+
+	err := runParallel(4, 10, func(i int) error {
+		if i%3 == 0 {
+			return fmt.Errorf("item %d", i)
+		}
+
+		return nil
+	})
+
+	var errs multiError
+	is.True(errors.As(err, &errs))
+	is.Equal(len(errs), 4)
+	is.Equal(err.Error(), "item 0\nitem 3\nitem 6\nitem 9")
+}
+
+func TestRunParallel_empty(t *testing.T) {
+	is := is.New(t)
+
+	err := runParallel(0, 0, func(i int) error {
+		return errors.New("unexpected call")
+	})
+	is.NoErr(err)
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+
+	"github.com/cloudogu/gomarkdoc"
+	"github.com/cloudogu/gomarkdoc/lang"
+)
+
+// errTargetsWithPackages is returned if targets are selected explicitly while
+// also providing packages on the command line.
+var errTargetsWithPackages = errors.New("gomarkdoc: packages cannot be provided together with --target")
+
+type (
+	// targetOptions defines a named generation target from the targets list of
+	// the configuration file. Options which are not set for a target are
+	// inherited from the top-level configuration.
+	targetOptions struct {
+		Name         string            `mapstructure:"name"`
+		Packages     []string          `mapstructure:"packages"`
+		Output       *string           `mapstructure:"output"`
+		IndexOutput  *string           `mapstructure:"indexOutput"`
+		Format       *string           `mapstructure:"format"`
+		CalloutStyle *string           `mapstructure:"calloutStyle"`
+		Embed        *bool             `mapstructure:"embed"`
+		Header       *string           `mapstructure:"header"`
+		HeaderFile   *string           `mapstructure:"headerFile"`
+		Footer       *string           `mapstructure:"footer"`
+		FooterFile   *string           `mapstructure:"footerFile"`
+		Template     map[string]string `mapstructure:"template"`
+		TemplateFile map[string]string `mapstructure:"templateFile"`
+		TemplateDir  *string           `mapstructure:"templateDir"`
+		Profile      *string           `mapstructure:"profile"`
+		Locale       *string           `mapstructure:"locale"`
+		MessagesFile *string           `mapstructure:"messagesFile"`
+		Order        *string           `mapstructure:"order"`
+		GroupOrder   []string          `mapstructure:"groupOrder"`
+		IncludeFiles []string          `mapstructure:"includeFiles"`
+	}
+
+	// sharedPackages holds the packages loaded by previous targets so that a
+	// package documented by multiple targets is only loaded once. It is safe
+	// for concurrent use.
+	sharedPackages struct {
+		mu   sync.Mutex
+		pkgs map[string]*lang.Package
+	}
+)
+
+// loadTargets reads the list of targets from the configuration file.
+func loadTargets() ([]targetOptions, error) {
+	var targets []targetOptions
+	if err := viper.UnmarshalKey("targets", &targets); err != nil {
+		return nil, fmt.Errorf("gomarkdoc: invalid targets configuration: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for i, t := range targets {
+		if t.Name == "" {
+			return nil, fmt.Errorf("gomarkdoc: target %d has no name", i+1)
+		}
+
+		if seen[t.Name] {
+			return nil, fmt.Errorf("gomarkdoc: duplicate target %s", t.Name)
+		}
+
+		seen[t.Name] = true
+	}
+
+	return targets, nil
+}
+
+// selectTargets returns the targets matching the provided names, in the order
+// in which they are configured. If no names are provided, all targets are
+// returned.
+func selectTargets(targets []targetOptions, names []string) ([]targetOptions, error) {
+	if len(names) == 0 {
+		return targets, nil
+	}
+
+	selected := make(map[string]bool)
+	for _, name := range names {
+		selected[name] = true
+	}
+
+	var result []targetOptions
+	for _, t := range targets {
+		if selected[t.Name] {
+			result = append(result, t)
+			delete(selected, t.Name)
+		}
+	}
+
+	if len(selected) > 0 {
+		var unknown []string
+		for _, name := range names {
+			if selected[name] {
+				unknown = append(unknown, name)
+			}
+		}
+
+		return nil, fmt.Errorf("gomarkdoc: unknown target: %s", strings.Join(unknown, ", "))
+	}
+
+	return result, nil
+}
+
+// apply creates the options for running the target from the provided
+// top-level options.
+func (t targetOptions) apply(opts commandOptions) commandOptions {
+	if t.Output != nil {
+		opts.output = *t.Output
+	}
+
+	if t.IndexOutput != nil {
+		opts.indexOutput = *t.IndexOutput
+	}
+
+	if t.Format != nil {
+		opts.format = *t.Format
+	}
+
+	if t.CalloutStyle != nil {
+		opts.calloutStyle = *t.CalloutStyle
+	}
+
+	if t.Embed != nil {
+		opts.embed = *t.Embed
+	}
+
+	// Setting either the content or the file replaces both, since the content
+	// would otherwise take precedence over the file of the target.
+	if t.Header != nil || t.HeaderFile != nil {
+		opts.header = valueOrEmpty(t.Header)
+		opts.headerFile = valueOrEmpty(t.HeaderFile)
+	}
+
+	if t.Footer != nil || t.FooterFile != nil {
+		opts.footer = valueOrEmpty(t.Footer)
+		opts.footerFile = valueOrEmpty(t.FooterFile)
+	}
+
+	if t.Template != nil {
+		opts.templateOverrides = t.Template
+	}
+
+	if t.TemplateFile != nil {
+		opts.templateFileOverrides = t.TemplateFile
+	}
+
+	if t.TemplateDir != nil {
+		opts.templateDir = *t.TemplateDir
+	}
+
+	if t.Profile != nil {
+		opts.profile = *t.Profile
+	}
+
+	if t.Locale != nil {
+		opts.locale = *t.Locale
+	}
+
+	if t.MessagesFile != nil {
+		opts.messagesFile = *t.MessagesFile
+	}
+
+	if t.Order != nil {
+		opts.order = *t.Order
+	}
+
+	if t.GroupOrder != nil {
+		opts.groupOrder = t.GroupOrder
+	}
+
+	if t.IncludeFiles != nil {
+		opts.includeFiles = t.IncludeFiles
+	}
+
+	return opts
+}
+
+func valueOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+// runTargets generates the documentation for each of the provided targets in
+// order. Packages are shared between targets wherever possible. The templates
+// of all targets are validated before any output is written.
+func runTargets(targets []targetOptions, opts commandOptions) error {
+	shared := &sharedPackages{pkgs: make(map[string]*lang.Package)}
+
+	renderers := make([]*gomarkdoc.Renderer, len(targets))
+	for i, t := range targets {
+		targetOpts := t.apply(opts)
+
+		if targetOpts.check && targetOpts.output == "" {
+			return fmt.Errorf("target %s: gomarkdoc: check mode cannot be run without an output set", t.Name)
+		}
+
+		if targetOpts.indexOutput != "" && targetOpts.output == "" {
+			return fmt.Errorf("target %s: gomarkdoc: index output cannot be written without an output set", t.Name)
+		}
+
+		out, err := newRenderer(targetOpts)
+		if err != nil {
+			return fmt.Errorf("target %s: %w", t.Name, err)
+		}
+
+		renderers[i] = out
+	}
+
+	for i, t := range targets {
+		targetOpts := t.apply(opts)
+
+		packages := t.Packages
+		if len(packages) == 0 {
+			packages = []string{"."}
+		}
+
+		if err := runCommand(packages, targetOpts, renderers[i], shared); err != nil {
+			return fmt.Errorf("target %s: %w", t.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// load returns the package previously stored for the key or loads and stores
+// it using the provided function. A nil store always loads the package.
+func (s *sharedPackages) load(key string, fn func() (*lang.Package, error)) (*lang.Package, error) {
+	if s == nil {
+		return fn()
+	}
+
+	s.mu.Lock()
+	pkg, ok := s.pkgs[key]
+	s.mu.Unlock()
+
+	if ok {
+		return pkg, nil
+	}
+
+	pkg, err := fn()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.pkgs[key] = pkg
+	s.mu.Unlock()
+
+	return pkg, nil
+}
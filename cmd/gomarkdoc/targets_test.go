@@ -0,0 +1,117 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/viper"
+)
+
+func TestTargetOptions_apply(t *testing.T) {
+	is := is.New(t)
+
+	output := "{{.Dir}}/API.md"
+	headerFile := "header.md"
+	locale := "de"
+	order := "tree"
+	calloutStyle := "aside"
+	opts := commandOptions{
+		output:       "{{.Dir}}/README.md",
+		format:       "github",
+		header:       "top-level header",
+		footer:       "top-level footer",
+		includeFiles: []string{"a.go"},
+	}
+
+	applied := targetOptions{
+		Name:         "api",
+		Output:       &output,
+		HeaderFile:   &headerFile,
+		Locale:       &locale,
+		Order:        &order,
+		GroupOrder:   []string{"core"},
+		CalloutStyle: &calloutStyle,
+	}.apply(opts)
+
+	is.Equal(applied.output, output)
+	is.Equal(applied.format, "github")
+	is.Equal(applied.header, "")
+	is.Equal(applied.headerFile, headerFile)
+	is.Equal(applied.footer, "top-level footer")
+	is.Equal(applied.includeFiles, []string{"a.go"})
+	is.Equal(applied.locale, "de")
+	is.Equal(applied.order, "tree")
+	is.Equal(applied.groupOrder, []string{"core"})
+	is.Equal(applied.calloutStyle, "aside")
+}
+
+func TestSelectTargets(t *testing.T) {
+	is := is.New(t)
+
+	targets := []targetOptions{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+
+	selected, err := selectTargets(targets, nil)
+	is.NoErr(err)
+	is.Equal(len(selected), 3)
+
+	selected, err = selectTargets(targets, []string{"c", "a"})
+	is.NoErr(err)
+	is.Equal(selected, []targetOptions{{Name: "a"}, {Name: "c"}})
+
+	_, err = selectTargets(targets, []string{"a", "x", "y"})
+	is.Equal(err.Error(), "gomarkdoc: unknown target: x, y")
+}
+
+func TestCommand_targets(t *testing.T) {
+	is := is.New(t)
+	t.Cleanup(viper.Reset)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	dir := t.TempDir()
+	config := filepath.Join(dir, "gomarkdoc.yml")
+	err = os.WriteFile(config, []byte(`
+format: plain
+repository:
+  url: https://github.com/cloudogu/gomarkdoc
+  defaultBranch: master
+  path: /testData/
+targets:
+  - name: simple
+    packages: ["./simple"]
+    output: `+filepath.Join(dir, "simple.md")+`
+    header: "simple header"
+  - name: both
+    packages: ["./simple", "./nested"]
+    output: `+filepath.Join(dir, "both.md")+`
+    format: github
+`), 0664)
+	is.NoErr(err)
+
+	cmd := buildCommand()
+	cmd.SetArgs([]string{"--config", config, "--no-cache", "--target", "simple"})
+	is.NoErr(cmd.Execute())
+
+	simple, err := os.ReadFile(filepath.Join(dir, "simple.md"))
+	is.NoErr(err)
+	is.True(len(simple) > 0)
+	is.Equal(string(simple[:len("simple header")]), "simple header")
+
+	_, err = os.Stat(filepath.Join(dir, "both.md"))
+	is.True(os.IsNotExist(err)) // only the selected target ran
+
+	cmd = buildCommand()
+	cmd.SetArgs([]string{"--config", config, "--no-cache"})
+	is.NoErr(cmd.Execute())
+
+	both, err := os.ReadFile(filepath.Join(dir, "both.md"))
+	is.NoErr(err)
+	is.True(len(both) > len(simple))
+
+	cmd = buildCommand()
+	cmd.SetArgs([]string{"--config", config, "--target", "simple", "./simple"})
+	is.Equal(cmd.Execute(), errTargetsWithPackages)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudogu/gomarkdoc"
+)
+
+func buildTemplatesCommand(configFile *string, targetNames *[]string) *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "templates",
+		Short: "work with the templates used to render documentation",
+	}
+
+	command.AddCommand(&cobra.Command{
+		Use:   "validate",
+		Short: "check the configured templates for problems without writing any output",
+		Long: "Check the configured templates for problems without writing any output. Templates are parsed and " +
+			"executed against a synthetic package so that references to unknown fields or functions are " +
+			"reported with the template name, line and expression. If targets are configured, the templates " +
+			"of every selected target are checked.",
+		Args: cobra.NoArgs,
+		// Problems found in the templates are reported in detail, so the
+		// usage doesn't need to be printed.
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			buildConfig(*configFile)
+
+			var opts commandOptions
+			loadOptions(&opts)
+
+			return runValidateTemplates(cmd.OutOrStdout(), opts, *targetNames)
+		},
+	})
+
+	return command
+}
+
+// runValidateTemplates validates the templates of the selected targets, or the
+// top-level templates if no targets are configured, and writes every problem
+// found to w.
+func runValidateTemplates(w io.Writer, opts commandOptions, targetNames []string) error {
+	targets, err := loadTargets()
+	if err != nil {
+		return err
+	}
+
+	if len(targets) == 0 && len(targetNames) > 0 {
+		return errors.New("gomarkdoc: no targets are configured")
+	}
+
+	selected, err := selectTargets(targets, targetNames)
+	if err != nil {
+		return err
+	}
+
+	var problems int
+	report := func(prefix string, opts commandOptions) error {
+		_, err := newRenderer(opts)
+		if err == nil {
+			return nil
+		}
+
+		tmplErrs, ok := gomarkdoc.AsTemplateErrors(err)
+		if !ok {
+			return err
+		}
+
+		for _, tmplErr := range tmplErrs {
+			fmt.Fprintf(w, "%s%s\n", prefix, tmplErr)
+		}
+
+		problems += len(tmplErrs)
+		return nil
+	}
+
+	if len(selected) == 0 {
+		if err := report("", opts); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range selected {
+		if err := report(fmt.Sprintf("target %s: ", t.Name), t.apply(opts)); err != nil {
+			return fmt.Errorf("target %s: %w", t.Name, err)
+		}
+	}
+
+	if problems > 0 {
+		return fmt.Errorf("gomarkdoc: found %d template problem(s)", problems)
+	}
+
+	return nil
+}
+
+// newRenderer creates the renderer for the provided options and validates its
+// templates, so that template problems are reported before any output is
+// written.
+func newRenderer(opts commandOptions) (*gomarkdoc.Renderer, error) {
+	overrides, err := resolveOverrides(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := gomarkdoc.NewRenderer(overrides...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := out.Validate(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
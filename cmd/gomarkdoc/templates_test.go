@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/viper"
+)
+
+func TestTemplatesValidateCommand(t *testing.T) {
+	is := is.New(t)
+	t.Cleanup(viper.Reset)
+
+	dir := t.TempDir()
+	config := filepath.Join(dir, "gomarkdoc.yml")
+	err := os.WriteFile(config, []byte(`
+targets:
+  - name: valid
+  - name: broken
+    template:
+      structfield: "{{ .Name }}\n{{ .Unknown }}"
+`), 0664)
+	is.NoErr(err)
+
+	var out bytes.Buffer
+	cmd := buildCommand()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"templates", "validate", "--config", config})
+
+	err = cmd.Execute()
+	is.Equal(err.Error(), "gomarkdoc: found 1 template problem(s)")
+	is.Equal(
+		out.String(),
+		"target broken: structfield:2:3: at <.Unknown>: can't evaluate field Unknown in type *lang.Field\n",
+	)
+}
+
+func TestCommand_invalidTemplateWritesNothing(t *testing.T) {
+	is := is.New(t)
+	t.Cleanup(viper.Reset)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	output := filepath.Join(t.TempDir(), "README.md")
+
+	cmd := buildCommand()
+	cmd.SetArgs([]string{"./simple", "-o", output, "--config", filepath.Join(t.TempDir(), "none.yml"),
+		"-t", "type={{ .Unknown }}"})
+
+	err = cmd.Execute()
+	is.True(err != nil)
+
+	_, err = os.Stat(output)
+	is.True(os.IsNotExist(err)) // no output was written
+}
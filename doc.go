@@ -22,27 +22,52 @@
 //	generate markdown documentation for golang code
 //
 //	Usage:
-//	  gomarkdoc [flags] [package ...]
+//	  gomarkdoc [package ...] [flags]
+//	  gomarkdoc [command]
+//
+//	Available Commands:
+//	  diff        report the changes to the documented API between two git revisions
+//	  help        Help about any command
+//	  init        create a configuration file listing all available options
+//	  lint        report missing or broken documentation and the documentation coverage
+//	  templates   work with the templates used to render documentation
 //
 //	Flags:
+//	      --cache-dir string                   Directory in which to store the generation cache. Defaults to a gomarkdoc folder in the user cache directory.
+//	      --callout-style string               Style of callouts such as notes and warnings. Valid options: native (default) renders them as supported by the format, aside renders HTML aside elements (default "native")
 //	  -c, --check                              Check the output to see if it matches the generated documentation. --output must be specified to use this.
 //	      --config string                      File from which to load configuration (default: .gomarkdoc.yml)
-//	  -e, --embed                              Embed documentation into existing markdown files if available, otherwise append to file.
+//	  -e, --embed                              Embed documentation into existing files if available, otherwise append to file.
+//	      --exclude-deprecated                 Omit symbols, methods and fields whose documentation contains a "Deprecated:" paragraph.
 //	      --footer string                      Additional content to inject at the end of each output file.
 //	      --footer-file string                 File containing additional content to inject at the end of each output file.
 //	  -f, --format string                      Format to use for writing output data. Valid options: github (default), azure-devops, plain (default "github")
+//	      --group-order strings                Groups set with the //gomarkdoc:group directive which are listed first, in the provided order.
 //	      --header string                      Additional content to inject at the beginning of each output file.
 //	      --header-file string                 File containing additional content to inject at the beginning of each output file.
 //	  -h, --help                               help for gomarkdoc
 //	  -u, --include-unexported                 Output documentation for unexported symbols, methods and fields in addition to exported ones.
+//	      --index-output string                File to which an index of all documented packages is written, linking to the output of each package. --output must be specified to use this.
+//	  -j, --jobs int                           Number of packages to load and render concurrently. Defaults to the number of available CPUs.
+//	      --locale string                      Language of the text generated for the documentation, such as headings and titles. Valid options: de, en (default: en)
+//	      --messages-file string               JSON file mapping messages such as "type %s" to translations which override or extend those of the locale.
+//	      --no-cache                           Always regenerate all output files instead of skipping files whose inputs have not changed.
+//	      --order string                       Order of types, functions and struct fields. Valid options: alphabetical, declaration, file, group, tree (default: alphabetical types and functions, fields in declaration order)
 //	  -o, --output string                      File or pattern specifying where to write documentation output. Defaults to printing to stdout.
-//	      --repository.default-branch string   Manual override for the git repository URL used in place of automatic detection.
+//	      --profile string                     Set of built-in templates to use. Valid options: structs (struct and interface types, default), full (all symbols) (default "structs")
+//	      --repository.default-branch string   Manual override for the default branch of the git repository used in place of automatic detection.
 //	      --repository.path string             Manual override for the path from the root of the git repository used in place of automatic detection.
+//	      --repository.pin string              Pin source links to the HEAD commit (commit) or the nearest tag (tag) instead of the default branch. Valid options: branch, commit, tag
+//	      --repository.remote strings          Names of the git remotes to detect the repository URL and default branch from, in order of preference. Other remotes are used if none of them is usable. (default: origin)
+//	      --repository.ref string              Branch, tag or commit which source links point at in place of the default branch, such as the tag of a release.
 //	      --repository.url string              Manual override for the git repository URL used in place of automatic detection.
 //	      --tags strings                       Set of build tags to apply when choosing which files to include for documentation generation.
+//	      --target strings                     Name of a target from the configuration file to run. Runs all configured targets if not provided.
 //	  -t, --template stringToString            Custom template string to use for the provided template name instead of the default template. (default [])
+//	      --template-dir string                Directory of .gotxt template files overriding the default templates of the same name or adding new ones.
 //	      --template-file stringToString       Custom template file to use for the provided template name instead of the default template. (default [])
 //	  -v, --verbose count                      Log additional output from the execution of the command. Can be chained for additional verbosity.
+//	      --verify-examples                    Run the examples of each package with go test and fail if an example fails to build or its output doesn't match the documented output.
 //	      --version                            Print the version.
 //
 // The gomarkdoc command processes each of the provided packages, generating
@@ -87,6 +112,38 @@
 // PackageSpec struct in the github.com/cloudogu/gomarkdoc/cmd/gomarkdoc
 // package.
 //
+// When documenting several packages into separate files, the --index-output
+// option additionally writes an index of all of them. The index lists each
+// package in the order provided along with its summary and documented types,
+// linking to the output files relative to the location of the index:
+//
+//	gomarkdoc --output '{{.Dir}}/README.md' --index-output docs/README.md ./...
+//
+// The index is rendered with the modindex template and receives the header
+// and footer like any other output file.
+//
+// # Template Profiles
+//
+// By default, gomarkdoc documents only the struct and interface types of a
+// package along with the documentation of their fields and methods, which is
+// suited for configuration references and plugin contracts. The built-in templates are grouped into profiles, which can be
+// selected with the --profile option:
+//
+//   - structs: documents only struct and interface types along with their
+//     fields and methods (default).
+//
+//   - full:    documents every symbol of a package, including constants,
+//     variables, functions, all types and their methods. The index lists
+//     each kind of symbol in its own section.
+//
+// For example, the API documentation of a library can be generated with:
+//
+//	gomarkdoc --profile full --output '{{.Dir}}/README.md' ./...
+//
+// Template overrides replace the corresponding template of the selected
+// profile. The init command writes the templates of the selected profile when
+// used with --templates and sets the profile in the generated configuration.
+//
 // # Template Overrides
 //
 // The documentation information that is output is formatted using a series of
@@ -122,17 +179,211 @@
 //     symbol it represents, based on the standard naming conventions
 //     outlined in https://blog.golang.org/examples#TOC_4.
 //
+//   - structfield: generates documentation for a single documented field of a
+//     struct type, along with its examples.
+//
+//   - interfacemethod: generates documentation for a single documented
+//     element of an interface type, which may be a method, an embedded
+//     interface or a type set constraint. The Kind of the element is
+//     "method", "embedded" or "constraint" respectively.
+//
 //   - doc:     generates the freeform documentation block for any of the above
 //     structures that can contain a documentation section.
 //
 //   - import:  generates the import code used to pull in a package.
 //
+//   - modindex: generates the index of all documented packages written to
+//     the file provided with --index-output. Each of the packages is
+//     provided along with the Href of its output file.
+//
+//   - apidiff: generates the report of the diff command listing the changes
+//     to the documented API between two revisions.
+//
 // Overriding with the -t option uses a key-vaule pair mapping a template name
 // to the file containing the contents of the override template to use.
 // Specified template files must exist:
 //
 //	gomarkdoc -t package=custom-package.gotxt -t doc=custom-doc.gotxt .
 //
+// If you maintain several custom templates, you can keep them in a directory
+// and provide it with the --template-dir option instead. Every file with the
+// .gotxt extension in the directory is loaded as a template named after the
+// file. Files named after one of the templates above override it, while all
+// other files are registered as additional templates. These can be used from
+// any template with the template action or the include function, which makes
+// it easy to share fragments such as a table of struct fields between
+// templates:
+//
+//	gomarkdoc --template-dir ./templates .
+//
+// Overrides from --template and --template-file take precedence over the files
+// in the template directory.
+//
+// # Template Functions
+//
+// Besides the functions built into the text/template package, the following
+// functions are available to all templates. Functions operating on a value
+// accept it as their last argument, so they can be used in pipelines such as
+// {{ .Types | filterByPrefix "Config" | sortByName }}.
+//
+// Formatting functions render markdown constructs using the selected format:
+// bold, italic, strikethrough, codeSpan, header, rawHeader, codeBlock, link,
+// listEntry, accordion, accordionHeader, accordionTerminator, localHref,
+// codeHref, paragraph, callout and escape.
+//
+// The tr function translates a message into the configured language and
+// formats it with the provided arguments, e.g. {{ tr "type %s" .Name }}. See
+// Localization below.
+//
+// Layout functions help with structuring the output:
+//
+//   - add a b: adds two integers.
+//   - spacer, inlineSpacer: produce the separator between blocks and lines.
+//   - hangingIndent s n: indents all but the first line of s by n spaces.
+//   - include name data: renders the named template to a string.
+//   - iter list: wraps the entries of a list with First and Last flags.
+//
+// String functions:
+//
+//   - lower s, upper s, title s: change the case of s.
+//   - trim s: removes leading and trailing whitespace.
+//   - trimPrefix prefix s, trimSuffix suffix s: remove a prefix or suffix.
+//   - replace old new s: replaces all occurrences of old.
+//   - contains substr s, hasPrefix prefix s, hasSuffix suffix s: test s.
+//   - split sep s, join sep list: split a string or join a list of values.
+//   - repeat count s: repeats s count times.
+//   - regexReplace pattern repl s: replaces all matches of a regular expression,
+//     with repl supporting $1-style references to capture groups.
+//   - regexMatch pattern s: tests s against a regular expression.
+//
+// Value and collection functions:
+//
+//   - default def value: returns def if value is empty, otherwise value.
+//   - dict key value ...: builds a map, e.g. to pass several values to include.
+//   - list value ...: builds a list of values.
+//   - first list, last list: return the first or last entry of a list.
+//   - sortStrings list: sorts a list of strings.
+//   - sortByName list: sorts types, funcs, fields and the like by name.
+//   - orderBy order list: lists types, funcs or fields in one of the orders
+//     described in Ordering below, e.g. {{ range orderBy "tree" .Types }}.
+//   - filterByName pattern list, excludeByName pattern list: keep or remove the
+//     entries whose names match a glob pattern like "Config*".
+//   - filterByPrefix prefix list: keeps the entries whose names start with
+//     prefix.
+//
+// When using gomarkdoc programmatically, additional functions can be provided
+// with the WithTemplateFuncs renderer option.
+//
+// # Template Validation
+//
+// Before any output is written, all templates are parsed and executed against
+// a synthetic package containing every kind of documentation construct.
+// Problems such as references to unknown fields or functions are reported
+// with the name of the template, the line and column, and the failing
+// expression:
+//
+//	structfield:2:11: at <.Unknown.Name>: can't evaluate field Unknown in type *lang.Field
+//
+// If targets are configured, the templates of all targets are validated before
+// the first target is generated. To check templates without generating any
+// documentation, use the validate subcommand. It accepts the --config,
+// --target, --format and template options:
+//
+//	gomarkdoc templates validate
+//
+// Programmatically, the same checks are available through Renderer.Validate.
+//
+// # Localization
+//
+// The text generated by gomarkdoc, such as the headings of sections and the
+// titles of symbols, is English by default. Another language can be selected
+// with the --locale option. Built-in translations are available for German
+// (de):
+//
+//	gomarkdoc --locale de -o README.md .
+//
+// Messages are identified by their English text, which is a format string for
+// messages with arguments (e.g. "type %s"). A JSON file provided with
+// --messages-file overrides or extends the translations of the locale, and
+// allows using a locale without built-in translations:
+//
+//	{
+//	  "Index": "Übersicht",
+//	  "type %s": "Typ %s"
+//	}
+//
+// Custom templates translate their own text with the tr function. Messages
+// without a translation are rendered in English.
+//
+// # Directives
+//
+// The documentation of individual symbols can be customized with directives
+// in their doc comments. Like other directives, such as //go:generate, they
+// start without a space after the comment marker and are not part of the
+// rendered documentation:
+//
+//	// Server serves requests.
+//	//
+//	//gomarkdoc:title "The Server"
+//	type Server struct {
+//		// Addr is the address to listen on.
+//		//
+//		//gomarkdoc:group Network
+//		Addr string
+//
+//		// Debug enables debug output.
+//		//
+//		//gomarkdoc:hide
+//		Debug bool
+//	}
+//
+// The //gomarkdoc:hide directive omits a type, function, constant, variable,
+// struct field or interface method from the documentation. The
+// //gomarkdoc:title directive replaces the heading of a symbol with the
+// provided text, which may be quoted. The //gomarkdoc:group directive renders
+// struct fields in sections titled with the group name rather than in
+// declaration order. Fields without a group come first.
+//
+// Struct fields can be documented with examples, which are rendered beneath
+// the field. Example functions are named after the type and the field, such as
+// ExampleServer_Addr, optionally followed by a suffix like
+// ExampleServer_Addr_ipv6. Examples which aren't Go code, like the matching
+// entry of a configuration file, can be written in the doc comment as a
+// paragraph starting with "Example:" followed by a code block:
+//
+//	// Addr is the address to listen on.
+//	//
+//	// Example:
+//	//
+//	//	addr: localhost:8080
+//	Addr string
+//
+// # Ordering
+//
+// By default, types and functions are listed alphabetically, while struct
+// fields are listed in the order of their declaration. The --order option
+// selects another order for all of them:
+//
+//   - alphabetical: sorted by name.
+//   - declaration: in the order of declaration, with the files of the package
+//     sorted by name.
+//   - file: symbols of the same file together, with the files sorted by name
+//     and the symbols of a file sorted by name.
+//   - group: symbols of the same //gomarkdoc:group together. Symbols without a
+//     group come first, followed by the groups listed with --group-order and
+//     the remaining groups in the order of their first declaration.
+//   - tree: each type before the types it references, starting with the types
+//     no other type refers to. A configuration reference then follows the
+//     fields of the root configuration struct into the nested types.
+//
+// For example, to document the nested configuration types of a package
+// starting at its root configuration struct:
+//
+//	gomarkdoc --order tree -o docs/config.md ./config
+//
+// Templates can select the order of a single list with the orderBy function,
+// e.g. {{ range orderBy "declaration" .Funcs }}.
+//
 // # Additional Options
 //
 // As with the godoc tool itself, only exported symbols will be shown in
@@ -141,12 +392,34 @@
 //
 //	gomarkdoc -u -o README.md .
 //
+// Symbols, struct fields and interface methods whose documentation contains a
+// paragraph starting with "Deprecated:" are rendered with a deprecation notice
+// and are struck through in the index. To leave them out of the documentation
+// entirely, add the --exclude-deprecated flag.
+//
+//	gomarkdoc --exclude-deprecated -o README.md .
+//
+// Paragraphs of documentation comments starting with "Note:", "Warning:",
+// "Deprecated:" or "Security:" are rendered as callouts. The github format
+// uses GitHub's alert syntax (e.g. > [!WARNING]), the other formats render a
+// blockquote starting with the bold label of the callout. With
+// --callout-style aside, callouts are rendered as HTML aside elements in any
+// format instead, whose class is the kind of the callout (e.g. "warning") so
+// that sites can style them:
+//
+//	<aside class="warning">
+//
+//	**Warning:** Handle with care.
+//
+//	</aside>
+//
 // If you want to blend the documentation generated by gomarkdoc with your own
 // hand-written markdown, you can use the --embed/-e flag to change the
 // gomarkdoc tool into an append/embed mode. When documentation is generated,
 // gomarkdoc looks for a file in the location where the documentation is to be
 // written and embeds the documentation if present. Otherwise, the documentation
-// is appended to the end of the file.
+// is appended to the end of the file, surrounded by a pair of start and end
+// markers.
 //
 //	gomarkdoc -o README.md -e .
 //
@@ -164,6 +437,44 @@
 //
 //	<!-- gomarkdoc:embed:end -->
 //
+// A file may contain several of these markers. Each of them is rendered
+// independently and may select what it documents using the attributes pkg,
+// type and template:
+//
+//	<!-- gomarkdoc:embed:start pkg=./config type=Settings template=type -->
+//
+//	This content is replaced with the documentation of the Settings type
+//
+//	<!-- gomarkdoc:embed:end -->
+//
+// The pkg attribute selects the package to document in place of the packages
+// written to the file. Local paths are relative to the directory of the file.
+// The type attribute selects a single type of the package. The template
+// attribute names the template used to render the region, which defaults to
+// the "type" template for types, the "package" template for packages and the
+// "file" template otherwise. Values containing spaces can be quoted. The
+// attributes are kept on the start marker, so the regions are updated in
+// place on later runs.
+//
+// The comments holding the markers depend on the extension of the file.
+// Markdown, HTML and all other files use HTML comments as shown above. AsciiDoc
+// files (.adoc, .asciidoc) use // line comments. Go files use // line
+// comments and YAML, TOML, shell and Python files (.yaml, .yml, .toml, .sh,
+// .py) use # line comments, in which case the embedded documentation is
+// commented out as well, keeping the indentation of the start marker:
+//
+//	server:
+//	  # gomarkdoc:embed:start type=Server
+//	  # gomarkdoc:embed:end
+//	  port: 80
+//
+// Markers without a matching start or end marker, start markers within another
+// region and markers with unknown attributes are reported with their line
+// numbers, and no files are written in that case. Since each region is written
+// with its start and end markers, running gomarkdoc again leaves the files
+// unchanged as long as the documentation did not change. Documentation which
+// contains embed markers itself is rejected for the same reason.
+//
 // If you would like to include files that are part of a build tag, you can
 // specify build tags with the --tags flag. Tags are also supported through
 // GOFLAGS, though command line and configuration file definitions override tags
@@ -179,12 +490,38 @@
 //
 //	gomarkdoc -o README.md -c .
 //
+// Examples render their code along with the documented output, which can get
+// out of date without anyone noticing. With --verify-examples, the examples of
+// each package are run with go test before any documentation is written, and
+// gomarkdoc fails if an example's output doesn't match its "Output:" comment or
+// the examples fail to build:
+//
+//	gomarkdoc --verify-examples -o README.md ./...
+//
 // If you're experiencing difficulty with gomarkdoc or just want to get more
 // information about how it's executing underneath, you can add -v to show more
 // logs. This can be chained a second time to show even more verbose logs:
 //
 //	gomarkdoc -vv -o README.md .
 //
+// Packages are loaded and rendered concurrently. By default, one worker per
+// available CPU is used. The number of workers can be limited with the
+// --jobs/-j flag. Output is always written in the order in which the packages
+// were specified, and errors from all failing packages are reported together:
+//
+//	gomarkdoc -j 4 -o '{{.Dir}}/README.md' ./...
+//
+// When writing to output files, gomarkdoc keeps a cache of the inputs used to
+// generate each file: the go files of the documented packages, the build tags,
+// templates, format and the version of gomarkdoc. If none of these changed
+// and the output file still holds what was generated last time, the packages
+// for that file are not even parsed. Output files whose contents would not
+// change are never rewritten, so their modification times stay stable for
+// make-based pipelines. The cache is stored in the user cache directory unless
+// --cache-dir is provided, and can be bypassed with --no-cache:
+//
+//	gomarkdoc --no-cache -o '{{.Dir}}/README.md' ./...
+//
 // Some features of gomarkdoc rely on being able to detect information from the
 // git repository containing the project. Since individual local git
 // repositories may be configured differently from person to person, you may
@@ -195,6 +532,68 @@
 //
 //	gomarkdoc --repository.url "https://github.com/cloudogu/gomarkdoc" --repository.default-branch master --repository.path / -o README.md .
 //
+// The repository URL and default branch are detected from the remote named
+// origin. In fork-based workflows, the canonical remote can be preferred with
+// --repository.remote, which accepts several names in order of preference.
+// The remaining remotes are used if none of the named remotes provides the
+// information. The default branch is the branch referenced by the HEAD of the
+// remote. If the remote has no HEAD, which is the case for repositories which
+// were not cloned from it, the first of init.defaultBranch from the git
+// configuration, main and master which exists is used:
+//
+//	gomarkdoc --repository.remote upstream,origin -o README.md .
+//
+// Source links point at the default branch, so their line numbers drift as the
+// branch moves on. For versioned documentation, the links can be pinned to the
+// HEAD commit with --repository.pin commit or to the nearest tag reachable from
+// the HEAD commit with --repository.pin tag, which falls back to the commit if
+// there is no such tag. Release pipelines can provide the ref explicitly with
+// --repository.ref, whose kind is detected from the repository unless it is
+// set with --repository.pin:
+//
+//	gomarkdoc --repository.ref v1.2.0 -o README.md .
+//
+// # API Changes
+//
+// The diff command reports the changes to the documented API of packages
+// between two git revisions, which is useful for release notes. Both revisions
+// are checked out into temporary directories, so the working tree is left
+// untouched. The report lists added, removed and changed types, struct fields,
+// struct tags and documentation:
+//
+//	gomarkdoc diff v1.0.0 v1.1.0 ./...
+//
+// The packages are local paths within the git repository of the current
+// directory and default to the current directory. The report is printed to
+// stdout unless a file is provided with --output/-o, and is rendered with the
+// apidiff template in the configured format.
+//
+// # Documentation Lint
+//
+// The lint command reports problems in the documentation of packages along
+// with the share of documented symbols:
+//
+//	gomarkdoc lint ./...
+//
+// The following rules are checked for all symbols which would be documented,
+// so options like --include-unexported apply:
+//
+//   - missing-doc (warning): packages, types and funcs without documentation
+//   - missing-field-doc (warning): struct fields without documentation
+//   - doc-prefix (note): documentation which doesn't start with the name of
+//     the symbol, or "Package name" for packages
+//   - broken-doc-link (error): doc links like [Name] which refer to no symbol
+//     of the package and no imported package
+//
+// The report is printed to stdout unless a file is provided with
+// --output/-o. Besides the human readable format, --report-format json and
+// --report-format sarif are supported, the latter for code scanning tools. The
+// command fails if a problem at or above the level given with --fail-on is
+// found, which defaults to warning and can be disabled with none, or if the
+// coverage is below the percentage given with --min-coverage:
+//
+//	gomarkdoc lint --fail-on error --min-coverage 80 --report-format sarif -o lint.sarif ./...
+//
 // # Configuring via File
 //
 // If you want to reuse configuration options across multiple invocations, you
@@ -210,6 +609,44 @@
 // separated by =. Options provided on the command line override those provided
 // in the configuration file if an option is present in both.
 //
+// If a project generates several sets of documentation, for example with
+// different output patterns, formats or templates, they can be configured as a
+// list of named targets in the configuration file. Each target lists the
+// packages it documents and may set its own output, format, embed, header,
+// headerFile, footer, footerFile, template, templateFile, templateDir,
+// profile, locale, messagesFile, order, groupOrder and includeFiles options. Options that are not set for a target are inherited
+// from the top level of the configuration file and the command line:
+//
+//	output: "{{.Dir}}/README.md"
+//	targets:
+//	  - name: readme
+//	    packages: ["./..."]
+//	  - name: config-reference
+//	    packages: ["./config"]
+//	    output: docs/config.md
+//	    order: tree
+//	    includeFiles: ["settings.go"]
+//
+// When targets are configured, running gomarkdoc without any packages runs all
+// of them in order. Specific targets can be selected with the --target flag.
+// A package documented by multiple targets is only loaded once:
+//
+//	gomarkdoc --target config-reference
+//
+// To get started, the init command writes a .gomarkdoc.yml to the current
+// directory which lists every available option along with its description and
+// default value:
+//
+//	gomarkdoc init
+//
+// If you want to customize the templates, add the --templates flag. This
+// writes the default templates to the .gomarkdoc/templates directory and
+// references each of them as a templateFile override in the generated
+// configuration, so any change to these files is picked up right away.
+// Existing files are only replaced when --force is provided:
+//
+//	gomarkdoc init --templates
+//
 // # Programmatic Usage
 //
 // While most users will find the command line utility sufficient for their
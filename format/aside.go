@@ -0,0 +1,20 @@
+package format
+
+import (
+	"github.com/cloudogu/gomarkdoc/format/formatcore"
+	"github.com/cloudogu/gomarkdoc/lang"
+)
+
+// AsideCallouts renders callouts as HTML aside elements and leaves everything
+// else to the wrapped Format. The class of each aside is the kind of the
+// callout, e.g. "warning", so that sites rendering the markdown can style
+// callouts on their own.
+type AsideCallouts struct {
+	Format
+}
+
+// Callout formats a paragraph of the provided kind as an HTML aside element
+// starting with the label of the callout.
+func (f *AsideCallouts) Callout(kind lang.CalloutKind, text string) (string, error) {
+	return formatcore.HTMLAside(string(kind), labeledCallout(kind, text)), nil
+}
@@ -0,0 +1,23 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/cloudogu/gomarkdoc/format"
+	"github.com/cloudogu/gomarkdoc/lang"
+)
+
+func TestAsideCallouts_Callout(t *testing.T) {
+	is := is.New(t)
+
+	f := &format.AsideCallouts{Format: &format.GitHubFlavoredMarkdown{}}
+	res, err := f.Callout(lang.SecurityCallout, "Never log *secrets*.")
+	is.NoErr(err)
+	is.Equal(res, "<aside class=\"security\">\n\n**Security:** Never log *secrets*.\n\n</aside>")
+
+	res, err = f.Bold("text") // other elements are left to the wrapped format
+	is.NoErr(err)
+	is.Equal(res, "**text**")
+}
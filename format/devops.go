@@ -22,6 +22,21 @@ func (f *AzureDevOpsMarkdown) Bold(text string) (string, error) {
 	return formatcore.Bold(text), nil
 }
 
+// Italic converts the provided text to italic.
+func (f *AzureDevOpsMarkdown) Italic(text string) (string, error) {
+	return formatcore.Italic(text), nil
+}
+
+// CodeSpan wraps the provided code as inline code.
+func (f *AzureDevOpsMarkdown) CodeSpan(code string) (string, error) {
+	return formatcore.CodeSpan(code), nil
+}
+
+// Strikethrough marks the provided markdown text as deleted.
+func (f *AzureDevOpsMarkdown) Strikethrough(text string) (string, error) {
+	return formatcore.GFMStrikethrough(text), nil
+}
+
 // CodeBlock wraps the provided code as a code block and tags it with the
 // provided language (or no language if the empty string is provided).
 func (f *AzureDevOpsMarkdown) CodeBlock(language, code string) (string, error) {
@@ -83,11 +98,22 @@ func (f *AzureDevOpsMarkdown) CodeHref(loc lang.Location) (string, error) {
 		return "", err
 	}
 
+	// The version is prefixed with GB for branches, GT for tags and GC for
+	// commits.
+	ref, kind := loc.Repo.LinkRef()
+	version := "GB" + ref
+	switch kind {
+	case lang.TagRef:
+		version = "GT" + ref
+	case lang.CommitRef:
+		version = "GC" + ref
+	}
+
 	return fmt.Sprintf(
-		"%s?path=%s&version=GB%s&lineStyle=plain&line=%d&lineEnd=%d&lineStartColumn=%d&lineEndColumn=%d",
+		"%s?path=%s&version=%s&lineStyle=plain&line=%d&lineEnd=%d&lineStartColumn=%d&lineEndColumn=%d",
 		loc.Repo.Remote,
 		url.PathEscape(filepath.ToSlash(p)),
-		loc.Repo.DefaultBranch,
+		version,
 		loc.Start.Line,
 		loc.End.Line,
 		loc.Start.Col,
@@ -136,6 +162,12 @@ func (f *AzureDevOpsMarkdown) Paragraph(text string) (string, error) {
 	return formatcore.Paragraph(text), nil
 }
 
+// Callout formats a paragraph of the provided kind as a blockquote starting
+// with the label of the callout.
+func (f *AzureDevOpsMarkdown) Callout(kind lang.CalloutKind, text string) (string, error) {
+	return formatcore.Blockquote(labeledCallout(kind, text)), nil
+}
+
 // Escape escapes special markdown characters from the provided text.
 func (f *AzureDevOpsMarkdown) Escape(text string) string {
 	return formatcore.Escape(text)
@@ -19,6 +19,33 @@ func TestBold(t *testing.T) {
 	is.Equal(res, "**sample text**")
 }
 
+func TestItalic(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AzureDevOpsMarkdown
+	res, err := f.Italic("sample_text")
+	is.NoErr(err)
+	is.Equal(res, "*sample\\_text*")
+}
+
+func TestCodeSpan(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AzureDevOpsMarkdown
+	res, err := f.CodeSpan("a_b")
+	is.NoErr(err)
+	is.Equal(res, "`a_b`")
+}
+
+func TestStrikethrough(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AzureDevOpsMarkdown
+	res, err := f.Strikethrough("[type Config](<#type-config>)")
+	is.NoErr(err)
+	is.Equal(res, "~~[type Config](<#type-config>)~~")
+}
+
 func TestCodeBlock(t *testing.T) {
 	is := is.New(t)
 
@@ -139,6 +166,44 @@ func TestCodeHref(t *testing.T) {
 	is.Equal(res, "https://dev.azure.com/org/project/_git/repo?path=subdir%2Ffile.go&version=GBmaster&lineStyle=plain&line=12&lineEnd=14&lineStartColumn=1&lineEndColumn=43")
 }
 
+func TestCodeHref_ref(t *testing.T) {
+	tests := []struct {
+		kind    lang.RefKind
+		ref     string
+		version string
+	}{
+		{lang.BranchRef, "release", "GBrelease"},
+		{lang.TagRef, "v1.2.0", "GTv1.2.0"},
+		{lang.CommitRef, "0123456789abcdef0123456789abcdef01234567", "GC0123456789abcdef0123456789abcdef01234567"},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.kind), func(t *testing.T) {
+			is := is.New(t)
+
+			wd, err := filepath.Abs(".")
+			is.NoErr(err)
+
+			var f format.AzureDevOpsMarkdown
+			res, err := f.CodeHref(lang.Location{
+				Start:    lang.Position{Line: 12, Col: 1},
+				End:      lang.Position{Line: 14, Col: 43},
+				Filepath: filepath.Join(wd, "file.go"),
+				WorkDir:  wd,
+				Repo: &lang.Repo{
+					Remote:        "https://dev.azure.com/org/project/_git/repo",
+					DefaultBranch: "master",
+					PathFromRoot:  "/",
+					Ref:           test.ref,
+					RefKind:       test.kind,
+				},
+			})
+			is.NoErr(err)
+			is.Equal(res, "https://dev.azure.com/org/project/_git/repo?path=file.go&version="+test.version+"&lineStyle=plain&line=12&lineEnd=14&lineStartColumn=1&lineEndColumn=43")
+		})
+	}
+}
+
 func TestCodeHref_noRepo(t *testing.T) {
 	is := is.New(t)
 
@@ -193,3 +258,12 @@ func TestListEntry_empty(t *testing.T) {
 	is.NoErr(err)
 	is.Equal(res, "")
 }
+
+func TestCallout(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AzureDevOpsMarkdown
+	res, err := f.Callout(lang.WarningCallout, "Some text.")
+	is.NoErr(err)
+	is.Equal(res, "> **Warning:** Some text.")
+}
@@ -1,6 +1,11 @@
 package format
 
-import "github.com/cloudogu/gomarkdoc/lang"
+import (
+	"fmt"
+
+	"github.com/cloudogu/gomarkdoc/format/formatcore"
+	"github.com/cloudogu/gomarkdoc/lang"
+)
 
 // Format is a generic interface for formatting documentation contents in a
 // particular way.
@@ -8,6 +13,17 @@ type Format interface {
 	// Bold converts the provided text to bold
 	Bold(text string) (string, error)
 
+	// Italic converts the provided text to italic.
+	Italic(text string) (string, error)
+
+	// CodeSpan wraps the provided code as inline code.
+	CodeSpan(code string) (string, error)
+
+	// Strikethrough marks the provided markdown text as deleted, e.g. to
+	// identify deprecated symbols. The text is not escaped. Formats without
+	// support for strikethrough return the text unchanged.
+	Strikethrough(text string) (string, error)
+
 	// CodeBlock wraps the provided code as a code block and tags it with the
 	// provided language (or no language if the empty string is provided).
 	CodeBlock(language, code string) (string, error)
@@ -59,6 +75,22 @@ type Format interface {
 	// Paragraph formats a paragraph with the provided text as the contents.
 	Paragraph(text string) (string, error)
 
+	// Callout formats a paragraph of the provided kind (e.g. a note or a
+	// warning) so that it stands out from the surrounding text. The text does
+	// not include the prefix of the callout and is not escaped.
+	Callout(kind lang.CalloutKind, text string) (string, error)
+
 	// Escape escapes special markdown characters from the provided text.
 	Escape(text string) string
 }
+
+// labeledCallout prefixes the text of a callout with its label in bold, e.g.
+// "**Warning:** text".
+func labeledCallout(kind lang.CalloutKind, text string) string {
+	label := formatcore.Bold(fmt.Sprintf("%s:", kind.Label()))
+	if text == "" {
+		return label
+	}
+
+	return fmt.Sprintf("%s %s", label, text)
+}
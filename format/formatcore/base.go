@@ -19,6 +19,40 @@ func Bold(text string) string {
 	return fmt.Sprintf("**%s**", Escape(text))
 }
 
+// Italic converts the provided text to italic.
+func Italic(text string) string {
+	if text == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("*%s*", Escape(text))
+}
+
+// CodeSpan wraps the provided code as inline code. Code containing backticks
+// is wrapped in double backticks.
+func CodeSpan(code string) string {
+	if code == "" {
+		return ""
+	}
+
+	if strings.Contains(code, "`") {
+		return fmt.Sprintf("`` %s ``", code)
+	}
+
+	return fmt.Sprintf("`%s`", code)
+}
+
+// GFMStrikethrough marks the provided text as deleted. The text is expected to
+// be markdown already and is not escaped, so that links and other inline
+// elements can be struck through.
+func GFMStrikethrough(text string) string {
+	if text == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("~~%s~~", text)
+}
+
 // CodeBlock wraps the provided code as a code block. Language syntax
 // highlighting is not supported.
 func CodeBlock(code string) string {
@@ -120,6 +154,35 @@ func GFMAccordionTerminator() string {
 	return "</p>\n</details>"
 }
 
+// Blockquote quotes the provided markdown text. Each line of the text is
+// prefixed with the blockquote marker.
+func Blockquote(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = fmt.Sprintf("> %s", line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// GFMAlert generates an alert of the provided type (e.g. NOTE or WARNING) with
+// the provided markdown text as its contents, using the alert syntax from
+// GitHub Flavored Markdown.
+func GFMAlert(alert, text string) string {
+	return fmt.Sprintf("> [!%s]\n%s", alert, Blockquote(text))
+}
+
+// HTMLAside wraps the provided markdown text in an HTML aside element with the
+// provided class. The text is separated from the element by blank lines so
+// that it is still rendered as markdown.
+func HTMLAside(class, text string) string {
+	return fmt.Sprintf("<aside class=\"%s\">\n\n%s\n\n</aside>", class, text)
+}
+
 // Paragraph formats a paragraph with the provided text as the contents
 func Paragraph(text string) string {
 	return text
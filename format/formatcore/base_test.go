@@ -99,3 +99,23 @@ func TestEscape(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockquote(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(Blockquote("Line 1\nLine 2\n\nLine 3"), "> Line 1\n> Line 2\n>\n> Line 3")
+}
+
+func TestHTMLAside(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(HTMLAside("note", "Some *text*."), "<aside class=\"note\">\n\nSome *text*.\n\n</aside>")
+}
+
+func TestCodeSpan(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(CodeSpan("x := 1"), "`x := 1`")
+	is.Equal(CodeSpan("a`b"), "`` a`b ``")
+	is.Equal(CodeSpan(""), "")
+}
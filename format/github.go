@@ -21,6 +21,21 @@ func (f *GitHubFlavoredMarkdown) Bold(text string) (string, error) {
 	return formatcore.Bold(text), nil
 }
 
+// Italic converts the provided text to italic.
+func (f *GitHubFlavoredMarkdown) Italic(text string) (string, error) {
+	return formatcore.Italic(text), nil
+}
+
+// CodeSpan wraps the provided code as inline code.
+func (f *GitHubFlavoredMarkdown) CodeSpan(code string) (string, error) {
+	return formatcore.CodeSpan(code), nil
+}
+
+// Strikethrough marks the provided markdown text as deleted.
+func (f *GitHubFlavoredMarkdown) Strikethrough(text string) (string, error) {
+	return formatcore.GFMStrikethrough(text), nil
+}
+
 // CodeBlock wraps the provided code as a code block and tags it with the
 // provided language (or no language if the empty string is provided).
 func (f *GitHubFlavoredMarkdown) CodeBlock(language, code string) (string, error) {
@@ -94,10 +109,12 @@ func (f *GitHubFlavoredMarkdown) CodeHref(loc lang.Location) (string, error) {
 		locStr = fmt.Sprintf("L%d-L%d", loc.Start.Line, loc.End.Line)
 	}
 
+	ref, _ := loc.Repo.LinkRef()
+
 	return fmt.Sprintf(
 		"%s/blob/%s/%s#%s",
 		loc.Repo.Remote,
-		loc.Repo.DefaultBranch,
+		ref,
 		filepath.ToSlash(p),
 		locStr,
 	), nil
@@ -139,6 +156,28 @@ func (f *GitHubFlavoredMarkdown) Paragraph(text string) (string, error) {
 	return formatcore.Paragraph(text), nil
 }
 
+// gfmAlerts maps the callout kinds to the GitHub alert types used to render
+// them. The labels of kinds without a matching alert type are rendered as part
+// of the text.
+var gfmAlerts = map[lang.CalloutKind]string{
+	lang.NoteCallout:       "NOTE",
+	lang.WarningCallout:    "WARNING",
+	lang.DeprecatedCallout: "WARNING",
+	lang.SecurityCallout:   "CAUTION",
+}
+
+// Callout formats a paragraph of the provided kind as a GitHub alert.
+func (f *GitHubFlavoredMarkdown) Callout(kind lang.CalloutKind, text string) (string, error) {
+	switch kind {
+	case lang.NoteCallout, lang.WarningCallout:
+		return formatcore.GFMAlert(gfmAlerts[kind], text), nil
+	case lang.DeprecatedCallout, lang.SecurityCallout:
+		return formatcore.GFMAlert(gfmAlerts[kind], labeledCallout(kind, text)), nil
+	default:
+		return formatcore.Blockquote(labeledCallout(kind, text)), nil
+	}
+}
+
 // Escape escapes special markdown characters from the provided text.
 func (f *GitHubFlavoredMarkdown) Escape(text string) string {
 	return formatcore.Escape(text)
@@ -19,6 +19,33 @@ func TestGitHubFlavoredMarkdown_Bold(t *testing.T) {
 	is.Equal(res, "**sample text**")
 }
 
+func TestGitHubFlavoredMarkdown_Italic(t *testing.T) {
+	is := is.New(t)
+
+	var f format.GitHubFlavoredMarkdown
+	res, err := f.Italic("sample_text")
+	is.NoErr(err)
+	is.Equal(res, "*sample\\_text*")
+}
+
+func TestGitHubFlavoredMarkdown_CodeSpan(t *testing.T) {
+	is := is.New(t)
+
+	var f format.GitHubFlavoredMarkdown
+	res, err := f.CodeSpan("a_b")
+	is.NoErr(err)
+	is.Equal(res, "`a_b`")
+}
+
+func TestGitHubFlavoredMarkdown_Strikethrough(t *testing.T) {
+	is := is.New(t)
+
+	var f format.GitHubFlavoredMarkdown
+	res, err := f.Strikethrough("[type Config](<#type-config>)")
+	is.NoErr(err)
+	is.Equal(res, "~~[type Config](<#type-config>)~~")
+}
+
 func TestGitHubFlavoredMarkdown_CodeBlock(t *testing.T) {
 	is := is.New(t)
 
@@ -139,6 +166,30 @@ func TestGitHubFlavoredMarkdown_CodeHref(t *testing.T) {
 	is.Equal(res, "https://dev.azure.com/org/project/_git/repo/blob/master/subdir/file.go#L12-L14")
 }
 
+func TestGitHubFlavoredMarkdown_CodeHref_ref(t *testing.T) {
+	is := is.New(t)
+
+	wd, err := filepath.Abs(".")
+	is.NoErr(err)
+
+	var f format.GitHubFlavoredMarkdown
+	res, err := f.CodeHref(lang.Location{
+		Start:    lang.Position{Line: 12, Col: 1},
+		End:      lang.Position{Line: 12, Col: 43},
+		Filepath: filepath.Join(wd, "file.go"),
+		WorkDir:  wd,
+		Repo: &lang.Repo{
+			Remote:        "https://github.com/org/repo",
+			DefaultBranch: "main",
+			PathFromRoot:  "/",
+			Ref:           "v1.2.0",
+			RefKind:       lang.TagRef,
+		},
+	})
+	is.NoErr(err)
+	is.Equal(res, "https://github.com/org/repo/blob/v1.2.0/file.go#L12")
+}
+
 func TestGitHubFlavoredMarkdown_CodeHref_noRepo(t *testing.T) {
 	is := is.New(t)
 
@@ -193,3 +244,28 @@ func TestGitHubFlavoredMarkdown_ListEntry_empty(t *testing.T) {
 	is.NoErr(err)
 	is.Equal(res, "")
 }
+
+func TestGitHubFlavoredMarkdown_Callout(t *testing.T) {
+	tests := []struct {
+		kind lang.CalloutKind
+		text string
+		out  string
+	}{
+		{kind: lang.NoteCallout, text: "Some text.", out: "> [!NOTE]\n> Some text."},
+		{kind: lang.WarningCallout, text: "Some text.", out: "> [!WARNING]\n> Some text."},
+		{kind: lang.DeprecatedCallout, text: "Use [Run](<#func-run>).", out: "> [!WARNING]\n> **Deprecated:** Use [Run](<#func-run>)."},
+		{kind: lang.SecurityCallout, text: "Line 1\n\nLine 2", out: "> [!CAUTION]\n> **Security:** Line 1\n>\n> Line 2"},
+		{kind: lang.DeprecatedCallout, text: "", out: "> [!WARNING]\n> **Deprecated:**"},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.kind), func(t *testing.T) {
+			is := is.New(t)
+
+			var f format.GitHubFlavoredMarkdown
+			res, err := f.Callout(test.kind, test.text)
+			is.NoErr(err)
+			is.Equal(res, test.out)
+		})
+	}
+}
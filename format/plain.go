@@ -16,6 +16,22 @@ func (f *PlainMarkdown) Bold(text string) (string, error) {
 	return formatcore.Bold(text), nil
 }
 
+// Italic converts the provided text to italic.
+func (f *PlainMarkdown) Italic(text string) (string, error) {
+	return formatcore.Italic(text), nil
+}
+
+// CodeSpan wraps the provided code as inline code.
+func (f *PlainMarkdown) CodeSpan(code string) (string, error) {
+	return formatcore.CodeSpan(code), nil
+}
+
+// Strikethrough returns the provided text unchanged, as strikethrough is not
+// supported in plain markdown.
+func (f *PlainMarkdown) Strikethrough(text string) (string, error) {
+	return text, nil
+}
+
 // CodeBlock wraps the provided code as a code block. The provided language is
 // ignored as it is not supported in plain markdown.
 func (f *PlainMarkdown) CodeBlock(language, code string) (string, error) {
@@ -95,6 +111,12 @@ func (f *PlainMarkdown) Paragraph(text string) (string, error) {
 	return formatcore.Paragraph(text), nil
 }
 
+// Callout formats a paragraph of the provided kind as a blockquote starting
+// with the label of the callout.
+func (f *PlainMarkdown) Callout(kind lang.CalloutKind, text string) (string, error) {
+	return formatcore.Blockquote(labeledCallout(kind, text)), nil
+}
+
 // Escape escapes special markdown characters from the provided text.
 func (f *PlainMarkdown) Escape(text string) string {
 	return formatcore.Escape(text)
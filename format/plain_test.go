@@ -19,6 +19,33 @@ func TestPlainMarkdown_Bold(t *testing.T) {
 	is.Equal(res, "**sample text**")
 }
 
+func TestPlainMarkdown_Italic(t *testing.T) {
+	is := is.New(t)
+
+	var f format.PlainMarkdown
+	res, err := f.Italic("sample_text")
+	is.NoErr(err)
+	is.Equal(res, "*sample\\_text*")
+}
+
+func TestPlainMarkdown_CodeSpan(t *testing.T) {
+	is := is.New(t)
+
+	var f format.PlainMarkdown
+	res, err := f.CodeSpan("a_b")
+	is.NoErr(err)
+	is.Equal(res, "`a_b`")
+}
+
+func TestPlainMarkdown_Strikethrough(t *testing.T) {
+	is := is.New(t)
+
+	var f format.PlainMarkdown
+	res, err := f.Strikethrough("[type Config](<#type-config>)")
+	is.NoErr(err)
+	is.Equal(res, "[type Config](<#type-config>)")
+}
+
 func TestPlainMarkdown_CodeBlock(t *testing.T) {
 	is := is.New(t)
 
@@ -181,3 +208,12 @@ func TestPlainMarkdown_ListEntry_empty(t *testing.T) {
 	is.NoErr(err)
 	is.Equal(res, "")
 }
+
+func TestPlainMarkdown_Callout(t *testing.T) {
+	is := is.New(t)
+
+	var f format.PlainMarkdown
+	res, err := f.Callout(lang.NoteCallout, "Some text.")
+	is.NoErr(err)
+	is.Equal(res, "> **Note:** Some text.")
+}
@@ -0,0 +1,316 @@
+package gomarkdoc
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/cloudogu/gomarkdoc/lang"
+)
+
+// named is implemented by all documentation constructs that can be sorted and
+// filtered by name, such as lang.Type, lang.Func and lang.Field.
+type named interface {
+	Name() string
+}
+
+// libraryFuncs provides the general purpose functions available to all
+// templates in addition to the formatting functions. The functions follow the
+// convention of accepting the value being operated on as the last argument so
+// that they can be used in pipelines. They are part of the documented template
+// interface, so existing functions must not change their behavior.
+func libraryFuncs() template.FuncMap {
+	return template.FuncMap{
+		// Strings
+		"lower":      strings.ToLower,
+		"upper":      strings.ToUpper,
+		"title":      titleCase,
+		"trim":       strings.TrimSpace,
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+		"join":       joinValues,
+		"repeat":     func(count int, s string) string { return strings.Repeat(s, count) },
+
+		"regexReplace": regexReplace,
+		"regexMatch":   regexMatch,
+
+		// Values
+		"default": defaultValue,
+		"dict":    dict,
+		"list":    func(values ...any) []any { return values },
+
+		// Collections
+		"first":          first,
+		"last":           last,
+		"sortStrings":    sortStrings,
+		"sortByName":     sortByName,
+		"orderBy":        orderBy,
+		"filterByName":   filterByName,
+		"excludeByName":  excludeByName,
+		"filterByPrefix": filterByPrefix,
+	}
+}
+
+// titleCase converts the first letter of every space-separated word to upper
+// case.
+func titleCase(s string) string {
+	var b strings.Builder
+	upperNext := true
+	for _, r := range s {
+		if upperNext {
+			r = unicode.ToUpper(r)
+		}
+
+		b.WriteRune(r)
+		upperNext = unicode.IsSpace(r)
+	}
+
+	return b.String()
+}
+
+func joinValues(sep string, list any) (string, error) {
+	items, err := sliceItems("join", list)
+	if err != nil {
+		return "", err
+	}
+
+	strs := make([]string, len(items))
+	for i, item := range items {
+		strs[i] = fmt.Sprint(item.Interface())
+	}
+
+	return strings.Join(strs, sep), nil
+}
+
+func regexReplace(pattern, repl, s string) (string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", fmt.Errorf("renderer: regexReplace: %w", err)
+	}
+
+	return re.ReplaceAllString(s, repl), nil
+}
+
+func regexMatch(pattern, s string) (bool, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, fmt.Errorf("renderer: regexMatch: %w", err)
+	}
+
+	return re.MatchString(s), nil
+}
+
+// defaultValue returns the value unless it is empty (the zero value, or an
+// empty string, slice or map), in which case the default is returned.
+func defaultValue(def, value any) any {
+	if isEmpty(value) {
+		return def
+	}
+
+	return value
+}
+
+func isEmpty(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.String:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+func dict(pairs ...any) (map[string]any, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("renderer: dict requires an even number of arguments")
+	}
+
+	m := make(map[string]any, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("renderer: dict keys must be strings, got %T", pairs[i])
+		}
+
+		m[key] = pairs[i+1]
+	}
+
+	return m, nil
+}
+
+func first(list any) (any, error) {
+	items, err := sliceItems("first", list)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+
+	return items[0].Interface(), nil
+}
+
+func last(list any) (any, error) {
+	items, err := sliceItems("last", list)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+
+	return items[len(items)-1].Interface(), nil
+}
+
+func sortStrings(list []string) []string {
+	sorted := make([]string, len(list))
+	copy(sorted, list)
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+// sortByName returns a copy of the list sorted alphabetically by the names of
+// its entries. The original order is kept for entries with the same name.
+func sortByName(list any) (any, error) {
+	items, err := namedItems("sortByName", list)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Interface().(named).Name() < items[j].Interface().(named).Name()
+	})
+
+	return makeSlice(list, items), nil
+}
+
+// orderBy returns a copy of the list of types, funcs or fields in the order
+// with the provided name, such as "declaration" or "tree". See lang.Order for
+// the available orders.
+func orderBy(name string, list any) (any, error) {
+	order, err := lang.ParseOrder(name)
+	if err != nil {
+		return nil, fmt.Errorf("renderer: orderBy: %w", err)
+	}
+
+	switch l := list.(type) {
+	case []*lang.Type:
+		return lang.OrderTypes(order, l), nil
+	case []*lang.Func:
+		return lang.OrderFuncs(order, l), nil
+	case []*lang.Field:
+		return lang.OrderFields(order, l), nil
+	default:
+		return nil, fmt.Errorf("renderer: orderBy only accepts types, funcs or fields, got %T", list)
+	}
+}
+
+// filterByName keeps the entries of the list whose names match the provided
+// glob pattern, using the syntax of path.Match.
+func filterByName(pattern string, list any) (any, error) {
+	return filterNamed("filterByName", list, func(name string) (bool, error) {
+		return path.Match(pattern, name)
+	})
+}
+
+// excludeByName removes the entries of the list whose names match the
+// provided glob pattern, using the syntax of path.Match.
+func excludeByName(pattern string, list any) (any, error) {
+	return filterNamed("excludeByName", list, func(name string) (bool, error) {
+		match, err := path.Match(pattern, name)
+		return !match, err
+	})
+}
+
+// filterByPrefix keeps the entries of the list whose names start with the
+// provided prefix.
+func filterByPrefix(prefix string, list any) (any, error) {
+	return filterNamed("filterByPrefix", list, func(name string) (bool, error) {
+		return strings.HasPrefix(name, prefix), nil
+	})
+}
+
+func filterNamed(fn string, list any, keep func(name string) (bool, error)) (any, error) {
+	items, err := namedItems(fn, list)
+	if err != nil {
+		return nil, err
+	}
+
+	var kept []reflect.Value
+	for _, item := range items {
+		ok, err := keep(item.Interface().(named).Name())
+		if err != nil {
+			return nil, fmt.Errorf("renderer: %s: %w", fn, err)
+		}
+
+		if ok {
+			kept = append(kept, item)
+		}
+	}
+
+	return makeSlice(list, kept), nil
+}
+
+// sliceItems provides the entries of a slice or array.
+func sliceItems(fn string, list any) ([]reflect.Value, error) {
+	if list == nil {
+		return nil, nil
+	}
+
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("renderer: %s only accepts slices", fn)
+	}
+
+	items := make([]reflect.Value, v.Len())
+	for i := range items {
+		items[i] = v.Index(i)
+	}
+
+	return items, nil
+}
+
+// namedItems provides the entries of a slice whose entries have names.
+func namedItems(fn string, list any) ([]reflect.Value, error) {
+	items, err := sliceItems(fn, list)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		if _, ok := item.Interface().(named); !ok {
+			return nil, fmt.Errorf("renderer: %s requires entries with a Name method, got %s", fn, item.Type())
+		}
+	}
+
+	return items, nil
+}
+
+// makeSlice creates a slice of the same type as the original list holding the
+// provided entries.
+func makeSlice(list any, items []reflect.Value) any {
+	if list == nil {
+		return nil
+	}
+
+	typ := reflect.TypeOf(list)
+	if typ.Kind() == reflect.Array {
+		typ = reflect.SliceOf(typ.Elem())
+	}
+
+	s := reflect.MakeSlice(typ, 0, len(items))
+	for _, item := range items {
+		s = reflect.Append(s, item)
+	}
+
+	return s.Interface()
+}
@@ -0,0 +1,120 @@
+package gomarkdoc_test
+
+import (
+	"go/doc"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/matryer/is"
+
+	"github.com/cloudogu/gomarkdoc"
+	"github.com/cloudogu/gomarkdoc/lang"
+)
+
+func TestLibraryFuncs(t *testing.T) {
+	tests := map[string]struct {
+		tmpl     string
+		expected string
+	}{
+		"lower":          {`{{ lower "ABC" }}`, "abc"},
+		"upper":          {`{{ "abc" | upper }}`, "ABC"},
+		"title":          {`{{ title "field of type" }}`, "Field Of Type"},
+		"trim":           {`{{ trim "  abc " }}`, "abc"},
+		"trimPrefix":     {`{{ .Name | trimPrefix "ex" }}`, "ample"},
+		"trimSuffix":     {`{{ trimSuffix "le" .Name }}`, "examp"},
+		"replace":        {`{{ replace "a" "o" "banana" }}`, "bonono"},
+		"contains":       {`{{ contains "amp" .Name }}`, "true"},
+		"hasPrefix":      {`{{ hasPrefix "x" .Name }}`, "false"},
+		"hasSuffix":      {`{{ hasSuffix "ple" .Name }}`, "true"},
+		"split":          {`{{ range split "," "a,b" }}[{{ . }}]{{ end }}`, "[a][b]"},
+		"join":           {`{{ list 1 "b" true | join ", " }}`, "1, b, true"},
+		"repeat":         {`{{ repeat 3 "ab" }}`, "ababab"},
+		"regexReplace":   {`{{ regexReplace "([A-Z])" "-$1" "ConfigValue" }}`, "-Config-Value"},
+		"regexMatch":     {`{{ regexMatch "^Conf" "Config" }}`, "true"},
+		"default empty":  {`{{ default "none" "" }}`, "none"},
+		"default value":  {`{{ .Name | default "none" }}`, "example"},
+		"dict":           {`{{ with dict "a" 1 "b" "two" }}{{ .a }}-{{ .b }}{{ end }}`, "1-two"},
+		"first":          {`{{ (first .Types).Name }}`, "Zeta"},
+		"last":           {`{{ (last .Types).Name }}`, "ConfigB"},
+		"sortStrings":    {`{{ split "," "c,a,b" | sortStrings | join "" }}`, "abc"},
+		"sortByName":     {`{{ range sortByName .Types }}{{ .Name }} {{ end }}`, "Alpha ConfigA ConfigB Zeta "},
+		"orderBy":        {`{{ range orderBy "alphabetical" .Types }}{{ .Name }} {{ end }}`, "Alpha ConfigA ConfigB Zeta "},
+		"filterByName":   {`{{ range filterByName "*a" .Types }}{{ .Name }} {{ end }}`, "Zeta Alpha "},
+		"excludeByName":  {`{{ range excludeByName "Config*" .Types }}{{ .Name }} {{ end }}`, "Zeta Alpha "},
+		"filterByPrefix": {`{{ range .Types | filterByPrefix "Config" }}{{ .Name }} {{ end }}`, "ConfigA ConfigB "},
+	}
+
+	pkg := lang.NewPackage(&lang.Config{}, &doc.Package{
+		Name: "example",
+		Types: []*doc.Type{
+			{Name: "Zeta"},
+			{Name: "Alpha"},
+			{Name: "ConfigA"},
+			{Name: "ConfigB"},
+		},
+	}, nil)
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+
+			r, err := gomarkdoc.NewRenderer(gomarkdoc.WithTemplateOverride("package", test.tmpl))
+			is.NoErr(err)
+
+			out, err := r.Package(pkg)
+			is.NoErr(err)
+			is.Equal(out, test.expected)
+		})
+	}
+}
+
+func TestLibraryFuncs_errors(t *testing.T) {
+	tests := map[string]struct {
+		tmpl string
+		err  string
+	}{
+		"dict odd":        {`{{ dict "a" }}`, "dict requires an even number of arguments"},
+		"dict key":        {`{{ dict 1 2 }}`, "dict keys must be strings"},
+		"sortByName type": {`{{ sortByName (list 1 2) }}`, "sortByName requires entries with a Name method"},
+		"regexReplace":    {`{{ regexReplace "(" "" "" }}`, "regexReplace"},
+		"filterByName":    {`{{ filterByName "[" .Types }}`, "syntax error in pattern"},
+		"orderBy":         {`{{ orderBy "random" .Types }}`, `invalid order "random"`},
+		"orderBy type":    {`{{ orderBy "tree" (list 1) }}`, "orderBy only accepts types, funcs or fields"},
+	}
+
+	pkg := lang.NewPackage(&lang.Config{}, &doc.Package{
+		Name:  "example",
+		Types: []*doc.Type{{Name: "Type"}},
+	}, nil)
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+
+			r, err := gomarkdoc.NewRenderer(gomarkdoc.WithTemplateOverride("package", test.tmpl))
+			is.NoErr(err)
+
+			_, err = r.Package(pkg)
+			is.True(err != nil)
+			is.True(strings.Contains(err.Error(), test.err))
+		})
+	}
+}
+
+func TestWithTemplateFuncs(t *testing.T) {
+	is := is.New(t)
+
+	r, err := gomarkdoc.NewRenderer(
+		gomarkdoc.WithTemplateFuncs(template.FuncMap{
+			"shout": func(s string) string { return s + "!" },
+			"upper": func(s string) string { return "custom" },
+		}),
+		gomarkdoc.WithTemplateOverride("package", `{{ shout .Name }} {{ upper .Name }}`),
+	)
+	is.NoErr(err)
+
+	out, err := r.Package(lang.NewPackage(&lang.Config{}, newDocPackage("example"), nil))
+	is.NoErr(err)
+	is.Equal(out, "example! custom")
+}
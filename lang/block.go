@@ -12,16 +12,22 @@ type (
 	// Block defines a single block element (e.g. paragraph, code block) in the
 	// documentation for a symbol or package.
 	Block struct {
-		cfg    *Config
-		kind   BlockKind
-		text   string
-		list   *List
-		inline bool
+		cfg     *Config
+		kind    BlockKind
+		text    string
+		list    *List
+		spans   []*Span
+		callout CalloutKind
+		inline  bool
 	}
 
 	// BlockKind identifies the type of block element represented by the
 	// corresponding Block.
 	BlockKind string
+
+	// CalloutKind identifies the convention used to start the paragraph of a
+	// block of kind CalloutBlock.
+	CalloutKind string
 )
 
 const (
@@ -36,8 +42,46 @@ const (
 
 	// ListBlock defines a block that represents an ordered or unordered list.
 	ListBlock BlockKind = "list"
+
+	// CalloutBlock defines a block that represents a paragraph which starts
+	// with a conventional prefix such as "Note:" or "Warning:" and is meant
+	// to stand out from the surrounding text.
+	CalloutBlock BlockKind = "callout"
+)
+
+const (
+	// NoteCallout identifies a paragraph starting with "Note:".
+	NoteCallout CalloutKind = "note"
+
+	// WarningCallout identifies a paragraph starting with "Warning:".
+	WarningCallout CalloutKind = "warning"
+
+	// DeprecatedCallout identifies a paragraph starting with "Deprecated:".
+	DeprecatedCallout CalloutKind = "deprecated"
+
+	// SecurityCallout identifies a paragraph starting with "Security:".
+	SecurityCallout CalloutKind = "security"
 )
 
+// calloutLabels holds the label of each callout kind, which is also the
+// prefix identifying the callout without the trailing colon.
+var calloutLabels = map[CalloutKind]string{
+	NoteCallout:       "Note",
+	WarningCallout:    "Warning",
+	DeprecatedCallout: "Deprecated",
+	SecurityCallout:   "Security",
+}
+
+// Label provides the human-readable label of the callout kind, such as
+// "Warning".
+func (k CalloutKind) Label() string {
+	if label, ok := calloutLabels[k]; ok {
+		return label
+	}
+
+	return string(k)
+}
+
 const officialGoPackagesURL = "https://pkg.go.dev"
 
 var (
@@ -51,14 +95,29 @@ var (
 // text contents and a flag indicating whether this block is part of an inline
 // element.
 func NewBlock(cfg *Config, kind BlockKind, text string, inline bool) *Block {
-	return &Block{cfg, kind, text, nil, inline}
+	return &Block{cfg, kind, text, nil, nil, "", inline}
+}
+
+// NewParagraphBlock creates a new paragraph block element with the given inline
+// elements and a flag indicating whether this block is part of an inline
+// element.
+func NewParagraphBlock(cfg *Config, spans []*Span, inline bool) *Block {
+	return &Block{cfg, ParagraphBlock, spansText(spans), nil, spans, "", inline}
+}
+
+// NewCalloutBlock creates a new callout block element of the provided callout
+// kind with the given inline elements, which do not include the prefix of the
+// callout, and a flag indicating whether this block is part of an inline
+// element.
+func NewCalloutBlock(cfg *Config, callout CalloutKind, spans []*Span, inline bool) *Block {
+	return &Block{cfg, CalloutBlock, spansText(spans), nil, spans, callout, inline}
 }
 
 // NewListBlock creates a new list block element and with the given list
 // definition and a flag indicating whether this block is part of an inline
 // element.
 func NewListBlock(cfg *Config, list *List, inline bool) *Block {
-	return &Block{cfg, ListBlock, "", list, inline}
+	return &Block{cfg, ListBlock, "", list, nil, "", inline}
 }
 
 // Level provides the default level that a block of kind HeaderBlock will render
@@ -86,6 +145,23 @@ func (b *Block) List() *List {
 	return b.list
 }
 
+// Spans provides the inline elements making up the contents of a paragraph or
+// callout block. Blocks created with NewBlock hold their text as a single plain
+// span.
+func (b *Block) Spans() []*Span {
+	if b.spans == nil && b.text != "" {
+		return []*Span{NewSpan(PlainSpan, b.text, "")}
+	}
+
+	return b.spans
+}
+
+// Callout provides the kind of callout for a callout block. Only relevant for
+// blocks of type CalloutBlock.
+func (b *Block) Callout() CalloutKind {
+	return b.callout
+}
+
 // Inline indicates whether the block is part of an inline element, such as a
 // list item.
 func (b *Block) Inline() bool {
@@ -102,64 +178,112 @@ func ParseBlocks(cfg *Config, blocks []comment.Block, inline bool) []*Block {
 		case *comment.Code:
 			res[i] = NewBlock(cfg.Inc(0), CodeBlock, v.Text, inline)
 		case *comment.Heading:
-			var b strings.Builder
-			printText(&b, v.Text...)
-			res[i] = NewBlock(cfg.Inc(0), HeaderBlock, b.String(), inline)
+			res[i] = NewBlock(cfg.Inc(0), HeaderBlock, plainText(v.Text...), inline)
 		case *comment.List:
 			list := NewList(cfg.Inc(0), v)
 			res[i] = NewListBlock(cfg.Inc(0), list, inline)
 		case *comment.Paragraph:
-			var b strings.Builder
-			printText(&b, v.Text...)
-			text := collapseWhitespace(b.String())
-			res[i] = NewBlock(cfg.Inc(0), ParagraphBlock, text, inline)
+			spans := ParseSpans(cfg, v.Text)
+			if callout, rest, ok := splitCalloutSpans(spans); ok {
+				res[i] = NewCalloutBlock(cfg.Inc(0), callout, rest, inline)
+			} else {
+				res[i] = NewParagraphBlock(cfg.Inc(0), spans, inline)
+			}
 		}
 	}
 
 	return res
 }
 
-func printText(b *strings.Builder, text ...comment.Text) {
-	for _, t := range text {
-		switch v := t.(type) {
-		case comment.Plain:
-			b.WriteString(string(v))
-		case comment.Italic:
-			b.WriteString(string(v))
-		case *comment.DocLink:
-			b.WriteString(printDocLink(v))
-		case *comment.Link:
-			b.WriteString(fmt.Sprintf("%s(%s)", v.Text, v.URL))
-		}
-	}
-}
-
-func printDocLink(docLink *comment.DocLink) string {
+// docLinkURL provides the URL of the symbol or package referenced by the doc
+// link.
+func docLinkURL(cfg *Config, docLink *comment.DocLink) string {
 	// case: link a symbol within the same type, f. i. [Volume]
-	text := fmt.Sprintf("%s", docLink.Text)
 	if docLink.ImportPath == "" {
-		return printLocalLink(text, fmt.Sprintf("Type %s", docLink.Name))
+		return localLinkURL(typeLinkTitle(cfg, docLink.Name))
 	}
 
 	// case: link a symbol within the same file or package [core.Volume]
-	if docLink.ImportPath == currentPackage {
-		return printLocalLink(text, fmt.Sprintf("Type %s", docLink.Name))
+	if docLink.ImportPath == cfg.pkgName {
+		return localLinkURL(typeLinkTitle(cfg, docLink.Name))
 	}
 
 	// case: link an external symbol outside the same file or package [os.File]
 	if docLink.Name != "" {
-		return fmt.Sprintf("%s(%s/%s#%s)", text, officialGoPackagesURL, docLink.ImportPath, docLink.Name)
+		return fmt.Sprintf("%s/%s#%s", officialGoPackagesURL, docLink.ImportPath, docLink.Name)
+	}
+	return fmt.Sprintf("%s/%s", officialGoPackagesURL, docLink.ImportPath)
+}
+
+// typeLinkTitle provides the title of the header of the type with the provided
+// name in the package being documented, which the anchor of the link is built
+// from. This is the custom title if one was set using the //gomarkdoc:title
+// directive.
+func typeLinkTitle(cfg *Config, name string) string {
+	for _, typ := range cfg.pkgTypes {
+		if typ.Name != name {
+			continue
+		}
+
+		if title := cfg.typeDirectives(typ.Decl).title; title != "" {
+			return title
+		}
+
+		break
 	}
-	return fmt.Sprintf("%s(%s/%s)", text, officialGoPackagesURL, docLink.ImportPath)
+
+	return cfg.catalog.Translate("type %s", name)
 }
 
-func printLocalLink(text, ref string) string {
+func localLinkURL(ref string) string {
 	result := formatcore.PlainText(ref)
 	result = strings.ToLower(result)
 	result = strings.TrimSpace(result)
 	result = githubMarkdownWhitespaceRegex.ReplaceAllString(result, "-")
 	result = githubMarkdownRemoveRegex.ReplaceAllString(result, "")
-	return fmt.Sprintf("%s(#%s)", text, result)
+	return fmt.Sprintf("#%s", result)
+}
+
+// splitCalloutSpans separates the prefix of a callout paragraph from its inline
+// elements. The prefix has to be part of the leading plain text.
+func splitCalloutSpans(spans []*Span) (CalloutKind, []*Span, bool) {
+	if len(spans) == 0 || spans[0].kind != PlainSpan {
+		return "", nil, false
+	}
+
+	callout, rest, ok := splitCallout(spans[0].text)
+	if !ok {
+		return "", nil, false
+	}
+
+	var res []*Span
+	if rest != "" {
+		res = append(res, NewSpan(PlainSpan, rest, ""))
+	}
+
+	return callout, append(res, spans[1:]...), true
+}
+
+// splitCallout separates the prefix of a callout paragraph (e.g. "Note:") from
+// the rest of the text. The last return value is false if the paragraph does
+// not start with the prefix of a callout.
+func splitCallout(text string) (CalloutKind, string, bool) {
+	for kind, label := range calloutLabels {
+		prefix := label + ":"
+		if !strings.HasPrefix(text, prefix) {
+			continue
+		}
+
+		rest := text[len(prefix):]
+		if rest != "" && rest[0] != ' ' {
+			// Something like "Note:s" is not a callout.
+			continue
+		}
+
+		return kind, strings.TrimLeft(rest, " "), true
+	}
+
+	return "", "", false
 }
 
 var whitespaceRegex = regexp.MustCompile(`\s+`)
@@ -0,0 +1,102 @@
+package lang_test
+
+import (
+	"go/doc"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/cloudogu/gomarkdoc/lang"
+)
+
+func TestParseBlocks_callout(t *testing.T) {
+	is := is.New(t)
+
+	doc := lang.NewDoc(&lang.Config{Level: 1}, `Some text.
+
+Note: Something to keep
+in mind.
+
+Warning: Something dangerous.
+
+Security:
+
+Deprecated: Use something else.
+
+Notes: Not a callout.
+
+  - Note: Callouts may appear in lists.`)
+
+	blocks := doc.Blocks()
+	is.Equal(len(blocks), 7)
+
+	is.Equal(blocks[0].Kind(), lang.ParagraphBlock)
+
+	is.Equal(blocks[1].Kind(), lang.CalloutBlock)
+	is.Equal(blocks[1].Callout(), lang.NoteCallout)
+	is.Equal(blocks[1].Text(), "Something to keep in mind.")
+
+	is.Equal(blocks[2].Callout(), lang.WarningCallout)
+	is.Equal(blocks[2].Text(), "Something dangerous.")
+
+	is.Equal(blocks[3].Callout(), lang.SecurityCallout)
+	is.Equal(blocks[3].Text(), "")
+
+	is.Equal(blocks[4].Callout(), lang.DeprecatedCallout)
+	is.Equal(blocks[4].Callout().Label(), "Deprecated")
+
+	is.Equal(blocks[5].Kind(), lang.ParagraphBlock) // the prefix has to be followed by a space
+	is.Equal(blocks[5].Text(), "Notes: Not a callout.")
+
+	item := blocks[6].List().Items()[0]
+	is.Equal(item.Blocks()[0].Kind(), lang.CalloutBlock)
+	is.True(item.Blocks()[0].Inline())
+}
+
+func TestParseBlocks_spans(t *testing.T) {
+	is := is.New(t)
+
+	doc := lang.NewDocWithDocLinkParser(&lang.Config{Level: 1}, "Uses a [Config] with\nthe `Run` method, an [os.File] and [the spec] (see https://go.dev).\n\n[the spec]: https://go.dev/ref/spec", "example", []*doc.Type{{Name: "Config"}})
+
+	blocks := doc.Blocks()
+	is.Equal(len(blocks), 1)
+
+	spans := blocks[0].Spans()
+
+	type span struct {
+		kind      lang.SpanKind
+		text, url string
+	}
+
+	var got []span
+	for _, s := range spans {
+		got = append(got, span{s.Kind(), s.Text(), s.URL()})
+	}
+
+	is.Equal(got, []span{
+		{lang.PlainSpan, "Uses a ", ""},
+		{lang.DocLinkSpan, "Config", "#type-config"},
+		{lang.PlainSpan, " with the ", ""},
+		{lang.CodeSpan, "Run", ""},
+		{lang.PlainSpan, " method, an ", ""},
+		{lang.DocLinkSpan, "os.File", "https://pkg.go.dev/os#File"},
+		{lang.PlainSpan, " and ", ""},
+		{lang.LinkSpan, "the spec", "https://go.dev/ref/spec"},
+		{lang.PlainSpan, " (see ", ""},
+		{lang.LinkSpan, "https://go.dev", "https://go.dev"},
+		{lang.PlainSpan, ").", ""},
+	})
+
+	is.Equal(blocks[0].Text(), "Uses a [Config](<#type-config>) with the `Run` method, an [os.File](<https://pkg.go.dev/os#File>) and [the spec](<https://go.dev/ref/spec>) (see [https://go.dev](<https://go.dev>)).")
+}
+
+func TestBlock_Spans_plain(t *testing.T) {
+	is := is.New(t)
+
+	b := lang.NewBlock(&lang.Config{}, lang.ParagraphBlock, "Some *text*", false)
+
+	spans := b.Spans()
+	is.Equal(len(spans), 1)
+	is.Equal(spans[0].Kind(), lang.PlainSpan)
+	is.Equal(spans[0].Text(), "Some *text*")
+}
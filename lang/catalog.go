@@ -0,0 +1,152 @@
+package lang
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+// Catalog translates the text generated for documentation, such as the titles
+// of symbols and the headings of sections. Messages are identified by their
+// English text (e.g. "type %s"), which is used as is if the catalog holds no
+// translation for it. Translations are format strings in the style of
+// fmt.Sprintf.
+//
+// A nil Catalog is valid and provides the English text.
+type Catalog struct {
+	locale   string
+	messages map[string]string
+}
+
+// DefaultLocale is the locale used if no locale is configured.
+const DefaultLocale = "en"
+
+// builtinMessages holds the messages of the locales shipped with gomarkdoc.
+// English needs no messages since the message IDs are the English text.
+var builtinMessages = map[string]map[string]string{
+	"en": {},
+	"de": {
+		"Index":         "Inhalt",
+		"Packages":      "Pakete",
+		"Constants":     "Konstanten",
+		"Variables":     "Variablen",
+		"Functions":     "Funktionen",
+		"Types":         "Typen",
+		"Output":        "Ausgabe",
+		"Example":       "Beispiel",
+		"Example (%s)":  "Beispiel (%s)",
+		"package %s":    "Paket %s",
+		"type %s":       "Typ %s",
+		"func %s":       "Funktion %s",
+		"func (%s) %s":  "Funktion (%s) %s",
+		"Field %s":      "Feld %s",
+		"Method %s":     "Methode %s",
+		"Embedded %s":   "Eingebettet %s",
+		"Constraint %s": "Einschränkung %s",
+
+		"API changes from %s to %s":                   "API-Änderungen von %s bis %s",
+		"The documented API did not change.":          "Die dokumentierte API hat sich nicht geändert.",
+		"The package was added.":                      "Das Paket wurde hinzugefügt.",
+		"The package was removed.":                    "Das Paket wurde entfernt.",
+		"The documentation of the package changed.":   "Die Dokumentation des Pakets hat sich geändert.",
+		"The type was added.":                         "Der Typ wurde hinzugefügt.",
+		"The type was removed.":                       "Der Typ wurde entfernt.",
+		"The documentation of the type changed.":      "Die Dokumentation des Typs hat sich geändert.",
+		"The declaration changed from:":               "Die Deklaration hat sich geändert von:",
+		"to:":                                         "zu:",
+		"Field %s of type %s with tag %s was added.":  "Feld %s vom Typ %s mit Tag %s wurde hinzugefügt.",
+		"Field %s of type %s was added.":              "Feld %s vom Typ %s wurde hinzugefügt.",
+		"Field %s was removed.":                       "Feld %s wurde entfernt.",
+		"The type of field %s changed from %s to %s.": "Der Typ von Feld %s hat sich von %s zu %s geändert.",
+		"The tag of field %s changed from %s to %s.":  "Der Tag von Feld %s hat sich von %s zu %s geändert.",
+		"The tag %s was added to field %s.":           "Der Tag %s wurde zu Feld %s hinzugefügt.",
+		"The tag %s was removed from field %s.":       "Der Tag %s wurde von Feld %s entfernt.",
+		"The documentation of field %s changed.":      "Die Dokumentation von Feld %s hat sich geändert.",
+	},
+}
+
+// Locales provides the names of the built-in locales in alphabetical order.
+func Locales() []string {
+	locales := make([]string, 0, len(builtinMessages))
+	for locale := range builtinMessages {
+		locales = append(locales, locale)
+	}
+
+	sort.Strings(locales)
+	return locales
+}
+
+// NewCatalog creates a catalog for the provided locale. The messages of a
+// built-in locale can be overridden or extended by the provided messages. A
+// locale which is not built in can be used as well if messages are provided
+// for it. An empty locale selects the DefaultLocale.
+func NewCatalog(locale string, messages map[string]string) (*Catalog, error) {
+	if locale == "" {
+		locale = DefaultLocale
+	}
+
+	builtin, ok := builtinMessages[locale]
+	if !ok && len(messages) == 0 {
+		return nil, fmt.Errorf(
+			"gomarkdoc: invalid locale %q. Valid options: %s",
+			locale,
+			strings.Join(Locales(), ", "),
+		)
+	}
+
+	merged := make(map[string]string, len(builtin)+len(messages))
+	for id, msg := range builtin {
+		merged[id] = msg
+	}
+
+	for id, msg := range messages {
+		merged[id] = msg
+	}
+
+	return &Catalog{locale, merged}, nil
+}
+
+// ReadMessagesFile reads messages from a JSON file holding an object which
+// maps message IDs to their translations, such as {"type %s": "Typ %s"}.
+func ReadMessagesFile(path string) (map[string]string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: couldn't read messages file: %w", err)
+	}
+
+	var messages map[string]string
+	if err := json.Unmarshal(b, &messages); err != nil {
+		return nil, fmt.Errorf("gomarkdoc: invalid messages file %s: %w", path, err)
+	}
+
+	return messages, nil
+}
+
+// Locale provides the locale of the catalog.
+func (c *Catalog) Locale() string {
+	if c == nil {
+		return DefaultLocale
+	}
+
+	return c.locale
+}
+
+// Translate provides the translation of the message with the provided ID,
+// formatted with the provided arguments. The ID itself is used if there is no
+// translation for it.
+func (c *Catalog) Translate(id string, args ...any) string {
+	msg := id
+	if c != nil {
+		if translated, ok := c.messages[id]; ok {
+			msg = translated
+		}
+	}
+
+	if len(args) == 0 {
+		return msg
+	}
+
+	return fmt.Sprintf(msg, args...)
+}
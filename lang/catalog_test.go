@@ -0,0 +1,92 @@
+package lang_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/cloudogu/gomarkdoc/lang"
+	"github.com/cloudogu/gomarkdoc/logger"
+)
+
+func TestCatalog_Translate(t *testing.T) {
+	is := is.New(t)
+
+	var english *lang.Catalog
+	is.Equal(english.Locale(), lang.DefaultLocale)
+	is.Equal(english.Translate("type %s", "Config"), "type Config")
+	is.Equal(english.Translate("100%"), "100%") // messages without arguments are not formatted
+
+	german, err := lang.NewCatalog("de", nil)
+	is.NoErr(err)
+	is.Equal(german.Locale(), "de")
+	is.Equal(german.Translate("type %s", "Config"), "Typ Config")
+	is.Equal(german.Translate("Index"), "Inhalt")
+	is.Equal(german.Translate("Unknown %s", "message"), "Unknown message")
+}
+
+func TestNewCatalog(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(lang.Locales(), []string{"de", "en"})
+
+	c, err := lang.NewCatalog("", nil)
+	is.NoErr(err)
+	is.Equal(c.Locale(), lang.DefaultLocale)
+
+	c, err = lang.NewCatalog("de", map[string]string{"Index": "Übersicht"})
+	is.NoErr(err)
+	is.Equal(c.Translate("Index"), "Übersicht") // overrides the built-in message
+	is.Equal(c.Translate("Types"), "Typen")
+
+	_, err = lang.NewCatalog("fr", nil)
+	is.Equal(err.Error(), `gomarkdoc: invalid locale "fr". Valid options: de, en`)
+
+	c, err = lang.NewCatalog("fr", map[string]string{"type %s": "type %s"})
+	is.NoErr(err) // locales without built-in messages are allowed if messages are provided
+	is.Equal(c.Locale(), "fr")
+}
+
+func TestReadMessagesFile(t *testing.T) {
+	is := is.New(t)
+
+	path := filepath.Join(t.TempDir(), "messages.json")
+	is.NoErr(os.WriteFile(path, []byte(`{"type %s": "Typ %s"}`), 0o644))
+
+	messages, err := lang.ReadMessagesFile(path)
+	is.NoErr(err)
+	is.Equal(messages, map[string]string{"type %s": "Typ %s"})
+
+	is.NoErr(os.WriteFile(path, []byte(`["type %s"]`), 0o644))
+	_, err = lang.ReadMessagesFile(path)
+	is.True(err != nil)
+}
+
+func TestPackageWithCatalog(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/function")
+	is.NoErr(err)
+
+	catalog, err := lang.NewCatalog("de", nil)
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithCatalog(catalog))
+	is.NoErr(err)
+
+	is.Equal(pkg.Title(), "Paket function")
+
+	for _, typ := range pkg.Types() {
+		switch typ.Name() {
+		case "Receiver":
+			is.Equal(typ.Title(), "Typ Receiver")
+			is.Equal(typ.Examples()[0].Title(), "Beispiel")
+			is.Equal(typ.Methods()[0].Title(), "Funktion (*Receiver) WithPtrReceiver")
+		case "Plugin":
+			is.Equal(typ.InterfaceMethods()[1].Title(), "Methode Init")
+		}
+	}
+}
@@ -4,14 +4,17 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/doc"
 	"go/token"
-	"io"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/cloudogu/gomarkdoc/logger"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
 )
 
 type (
@@ -24,6 +27,33 @@ type (
 		PkgDir  string
 		WorkDir string
 		Log     logger.Logger
+
+		// pkgName and pkgTypes describe the package being documented. They are
+		// used to resolve doc links ([Type], [pkg.Type]) within comments.
+		pkgName  string
+		pkgTypes []*doc.Type
+
+		// excludeDeprecated omits deprecated symbols from the lists of
+		// symbols provided by the package and its types.
+		excludeDeprecated bool
+
+		// catalog translates the titles of symbols. A nil catalog provides
+		// English titles.
+		catalog *Catalog
+
+		// directives holds the gomarkdoc directives of the declarations in
+		// the package, whose doc comments are removed by the doc package.
+		directives map[ast.Node]directives
+
+		// order defines the order of the types, funcs and struct fields
+		// provided by the package and its types. groupOrder lists the groups
+		// which come first for the GroupOrder and Type.FieldGroups.
+		order      Order
+		groupOrder []string
+
+		// remoteNames lists the names of the git remotes which the repository
+		// information is detected from, in order of preference.
+		remoteNames []string
 	}
 
 	// Repo represents information about a repository relevant to documentation
@@ -32,6 +62,14 @@ type (
 		Remote        string
 		DefaultBranch string
 		PathFromRoot  string
+
+		// Ref is the branch, tag or commit which source links point at in
+		// place of the DefaultBranch, such as the tag of a release. RefKind
+		// defines its kind, which is detected if not provided. If only the
+		// RefKind is set to CommitRef or TagRef, the Ref is resolved from the
+		// repository as described for ResolveRef.
+		Ref     string
+		RefKind RefKind
 	}
 
 	// Location holds information for identifying a position within a file and
@@ -83,8 +121,8 @@ func NewConfig(log logger.Logger, workDir string, pkgDir string, opts ...ConfigO
 		return nil, err
 	}
 
-	if cfg.Repo == nil || cfg.Repo.Remote == "" || cfg.Repo.DefaultBranch == "" || cfg.Repo.PathFromRoot == "" {
-		repo, err := getRepoForDir(log, cfg.WorkDir, cfg.PkgDir, cfg.Repo)
+	if cfg.Repo == nil || cfg.Repo.Remote == "" || !cfg.Repo.hasLinkRef() || cfg.Repo.PathFromRoot == "" {
+		repo, err := getRepoForDir(log, cfg.WorkDir, cfg.PkgDir, cfg.Repo, cfg.remoteNames)
 		if err != nil {
 			log.Infof("unable to resolve repository due to error: %s", err)
 			cfg.Repo = nil
@@ -102,6 +140,8 @@ func NewConfig(log logger.Logger, workDir string, pkgDir string, opts ...ConfigO
 		log.Debugf("skipping repository resolution because all values have manual overrides")
 	}
 
+	resolveRepoRef(log, cfg.PkgDir, cfg.Repo)
+
 	return cfg, nil
 }
 
@@ -114,6 +154,69 @@ func (c *Config) Inc(step int) *Config {
 		WorkDir: c.WorkDir,
 		Repo:    c.Repo,
 		Log:     c.Log,
+
+		pkgName:  c.pkgName,
+		pkgTypes: c.pkgTypes,
+
+		excludeDeprecated: c.excludeDeprecated,
+		catalog:           c.catalog,
+		directives:        c.directives,
+		order:             c.order,
+		groupOrder:        c.groupOrder,
+		remoteNames:       c.remoteNames,
+	}
+}
+
+// ConfigWithDeprecatedExcluded omits deprecated symbols, struct fields and
+// interface methods from the documentation. Symbols are deprecated if their
+// documentation comment contains a paragraph starting with "Deprecated:".
+func ConfigWithDeprecatedExcluded() ConfigOption {
+	return func(c *Config) error {
+		c.excludeDeprecated = true
+		return nil
+	}
+}
+
+// ConfigWithCatalog translates the titles of symbols, such as "type %s", using
+// the provided catalog.
+func ConfigWithCatalog(catalog *Catalog) ConfigOption {
+	return func(c *Config) error {
+		c.catalog = catalog
+		return nil
+	}
+}
+
+// ConfigWithOrder lists the types, funcs and struct fields of the package in
+// the provided order.
+func ConfigWithOrder(order Order) ConfigOption {
+	return func(c *Config) error {
+		if _, err := ParseOrder(string(order)); err != nil {
+			return err
+		}
+
+		c.order = order
+		return nil
+	}
+}
+
+// ConfigWithGroupOrder defines the order of the groups set using the
+// //gomarkdoc:group directive. The provided groups come first, followed by the
+// groups which are not listed in the order of their first declaration.
+func ConfigWithGroupOrder(groups []string) ConfigOption {
+	return func(c *Config) error {
+		c.groupOrder = groups
+		return nil
+	}
+}
+
+// ConfigWithRemoteNames defines the names of the git remotes which the
+// repository information is detected from, in order of preference. The
+// remaining remotes of the repository are used if none of them provides the
+// information. Without this option, the remote named "origin" is preferred.
+func ConfigWithRemoteNames(names []string) ConfigOption {
+	return func(c *Config) error {
+		c.remoteNames = names
+		return nil
 	}
 }
 
@@ -141,7 +244,13 @@ func ConfigWithRepoOverrides(overrides *Repo) ConfigOption {
 	}
 }
 
-func getRepoForDir(log logger.Logger, wd string, dir string, ri *Repo) (*Repo, error) {
+// hasLinkRef reports whether the repository information provides a ref for
+// source links without detecting the default branch.
+func (r *Repo) hasLinkRef() bool {
+	return r.DefaultBranch != "" || r.Ref != ""
+}
+
+func getRepoForDir(log logger.Logger, wd string, dir string, ri *Repo, remoteNames []string) (*Repo, error) {
 	if ri == nil {
 		ri = &Repo{}
 	}
@@ -170,8 +279,8 @@ func getRepoForDir(log logger.Logger, wd string, dir string, ri *Repo) (*Repo, e
 		ri.PathFromRoot = filepath.Join(string(filepath.Separator), p)
 	}
 
-	// No need to check remotes if we already have a url and a default branch
-	if ri.Remote != "" && ri.DefaultBranch != "" {
+	// No need to check remotes if we already have a url and a ref to link to
+	if ri.Remote != "" && ri.hasLinkRef() {
 		return ri, nil
 	}
 
@@ -180,79 +289,108 @@ func getRepoForDir(log logger.Logger, wd string, dir string, ri *Repo) (*Repo, e
 		return nil, err
 	}
 
-	for _, r := range remotes {
-		if repo, ok := processRemote(log, repo, r, *ri); ok {
-			ri = repo
-			break
+	if len(remotes) == 0 {
+		return nil, errors.New("no remotes found for repository")
+	}
+
+	branches := defaultBranchCandidates(log, repo)
+	for _, r := range rankRemotes(log, remotes, remoteNames) {
+		if repo, ok := processRemote(log, repo, r, *ri, branches); ok {
+			return repo, nil
 		}
 	}
 
-	// If there's no "origin", just use the first remote
-	if ri.DefaultBranch == "" || ri.Remote == "" {
-		if len(remotes) == 0 {
-			return nil, errors.New("no remotes found for repository")
+	return nil, errors.New("no usable remote found for repository")
+}
+
+// rankRemotes orders the remotes by the provided names, followed by the
+// remaining remotes sorted by name. Without names, the remote named "origin"
+// comes first.
+func rankRemotes(log logger.Logger, remotes []*git.Remote, names []string) []*git.Remote {
+	if len(names) == 0 {
+		names = []string{"origin"}
+	}
+
+	rank := make(map[string]int, len(names))
+	for i, name := range names {
+		if _, ok := rank[name]; !ok {
+			rank[name] = i
 		}
+	}
 
-		repo, ok := processRemote(log, repo, remotes[0], *ri)
-		if !ok {
-			return nil, errors.New("no remotes found for repository")
+	ranked := make([]*git.Remote, len(remotes))
+	copy(ranked, remotes)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i].Config().Name, ranked[j].Config().Name
+		ra, okA := rank[a]
+		rb, okB := rank[b]
+		switch {
+		case okA && okB:
+			return ra < rb
+		case okA != okB:
+			return okA
+		default:
+			return a < b
 		}
+	})
 
-		ri = repo
+	found := make(map[string]bool, len(remotes))
+	for _, r := range remotes {
+		found[r.Config().Name] = true
 	}
 
-	return ri, nil
-}
+	for _, name := range names {
+		if !found[name] {
+			log.Debugf("remote %s not found in repository", name)
+		}
+	}
 
-func processRemote(log logger.Logger, repository *git.Repository, remote *git.Remote, ri Repo) (*Repo, bool) {
-	repo := &ri
+	return ranked
+}
 
-	c := remote.Config()
+// defaultBranchCandidates provides the names of the branches which are
+// considered to be the default branch if the remote doesn't define its HEAD:
+// the init.defaultBranch of the git configuration, main and master.
+func defaultBranchCandidates(log logger.Logger, repo *git.Repository) []string {
+	var candidates []string
 
-	// TODO: configurable remote name?
-	if c.Name != "origin" || len(c.URLs) == 0 {
-		log.Debugf("skipping remote because it is not the origin or it has no URLs")
-		return nil, false
+	c, err := repo.ConfigScoped(config.GlobalScope)
+	if err != nil {
+		log.Debugf("unable to read git configuration: %s", err)
+	} else if c.Init.DefaultBranch != "" {
+		candidates = append(candidates, c.Init.DefaultBranch)
 	}
 
-	// Only detect the default branch if we don't already have one
-	if repo.DefaultBranch == "" {
-		refs, err := repository.References()
-		if err != nil {
-			log.Debugf("skipping remote %s because listing its refs failed: %s", c.URLs[0], err)
-			return nil, false
+	for _, name := range []string{"main", "master"} {
+		if len(candidates) == 0 || candidates[0] != name {
+			candidates = append(candidates, name)
 		}
+	}
 
-		prefix := fmt.Sprintf("refs/remotes/%s/", c.Name)
-		headRef := fmt.Sprintf("refs/remotes/%s/HEAD", c.Name)
-
-		for {
-			ref, err := refs.Next()
-			if err != nil {
-				if err == io.EOF {
-					break
-				}
+	return candidates
+}
 
-				log.Debugf("skipping remote %s because listing its refs failed: %s", c.URLs[0], err)
-				return nil, false
-			}
-			defer refs.Close()
+func processRemote(log logger.Logger, repository *git.Repository, remote *git.Remote, ri Repo, branches []string) (*Repo, bool) {
+	repo := &ri
 
-			if ref == nil {
-				break
-			}
+	c := remote.Config()
 
-			if string(ref.Name()) == headRef && strings.HasPrefix(string(ref.Target()), prefix) {
-				repo.DefaultBranch = strings.TrimPrefix(string(ref.Target()), prefix)
-				log.Debugf("found default branch %s for remote %s", repo.DefaultBranch, c.URLs[0])
-				break
-			}
-		}
+	if len(c.URLs) == 0 {
+		log.Debugf("skipping remote %s because it has no URLs", c.Name)
+		return nil, false
+	}
 
-		if repo.DefaultBranch == "" {
+	// Only detect the default branch if we don't already have one or a ref
+	// replacing it
+	if !repo.hasLinkRef() {
+		branch, ok := detectDefaultBranch(log, repository, c.Name, branches)
+		if !ok {
 			log.Debugf("skipping remote %s because no default branch was found", c.URLs[0])
 			return nil, false
 		}
+
+		log.Debugf("found default branch %s for remote %s", branch, c.URLs[0])
+		repo.DefaultBranch = branch
 	}
 
 	// If we already have the remote from an override, we don't need to detect.
@@ -270,6 +408,31 @@ func processRemote(log logger.Logger, repository *git.Repository, remote *git.Re
 	return repo, true
 }
 
+// detectDefaultBranch detects the default branch of the remote with the
+// provided name. This is the branch referenced by refs/remotes/<name>/HEAD if
+// present. Otherwise, the first of the candidate branches which exists as a
+// remote-tracking branch of the remote or as a local branch is used.
+func detectDefaultBranch(log logger.Logger, repository *git.Repository, remote string, candidates []string) (string, bool) {
+	prefix := fmt.Sprintf("refs/remotes/%s/", remote)
+
+	head, err := repository.Reference(plumbing.ReferenceName(prefix+"HEAD"), false)
+	if err == nil && head.Type() == plumbing.SymbolicReference && strings.HasPrefix(string(head.Target()), prefix) {
+		return strings.TrimPrefix(string(head.Target()), prefix), true
+	}
+
+	log.Debugf("remote %s has no HEAD, looking for branches named %s instead", remote, strings.Join(candidates, ", "))
+
+	for _, refPrefix := range []string{prefix, "refs/heads/"} {
+		for _, name := range candidates {
+			if _, err := repository.Reference(plumbing.ReferenceName(refPrefix+name), false); err == nil {
+				return name, true
+			}
+		}
+	}
+
+	return "", false
+}
+
 var (
 	sshRemoteRegex       = regexp.MustCompile(`^[\w-]+@([^:]+):(.+?)(?:\.git)?$`)
 	httpsRemoteRegex     = regexp.MustCompile(`^(https?://)(?:[^@/]+@)?([\w-.]+)(/.+?)?(?:\.git)?$`)
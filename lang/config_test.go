@@ -3,6 +3,9 @@ package lang
 import (
 	"testing"
 
+	"github.com/cloudogu/gomarkdoc/logger"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/matryer/is"
 )
 
@@ -55,3 +58,79 @@ func TestNormalizeRemote(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRepoForDir(t *testing.T) {
+	dir, repo := initTestRepo(t)
+	head := commitTestRepo(t, dir, repo, "first")
+
+	remote := func(name, url string) {
+		_, err := repo.CreateRemote(&config.RemoteConfig{Name: name, URLs: []string{url}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ref := func(r *plumbing.Reference) {
+		if err := repo.Storer.SetReference(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	remote("fork", "git@github.com:someone/repo.git")
+	ref(plumbing.NewHashReference("refs/remotes/fork/main", head))
+
+	remote("origin", "https://github.com/me/repo.git")
+	ref(plumbing.NewHashReference("refs/remotes/origin/develop", head))
+	ref(plumbing.NewSymbolicReference("refs/remotes/origin/HEAD", "refs/remotes/origin/develop"))
+
+	remote("upstream", "https://github.com/org/repo.git")
+
+	tests := map[string]struct {
+		names  []string
+		remote string
+		branch string
+	}{
+		"origin by default":  {nil, "https://github.com/me/repo", "develop"},
+		"ranked remote":      {[]string{"fork", "origin"}, "https://github.com/someone/repo", "main"},
+		"missing remote":     {[]string{"other", "fork"}, "https://github.com/someone/repo", "main"},
+		"local branch":       {[]string{"upstream"}, "https://github.com/org/repo", "master"},
+		"ranked before rest": {[]string{"origin", "upstream"}, "https://github.com/me/repo", "develop"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+
+			ri, err := getRepoForDir(logger.New(logger.ErrorLevel), dir, dir, nil, test.names)
+			is.NoErr(err)
+			is.Equal(ri.Remote, test.remote)
+			is.Equal(ri.DefaultBranch, test.branch)
+			is.Equal(ri.PathFromRoot, "/")
+		})
+	}
+}
+
+func TestGetRepoForDir_fallback(t *testing.T) {
+	is := is.New(t)
+
+	dir, repo := initTestRepo(t)
+	head := commitTestRepo(t, dir, repo, "first")
+
+	// Branches which are neither main nor master are only found through the
+	// HEAD of the remote
+	is.NoErr(repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/trunk", head)))
+	is.NoErr(repo.Storer.RemoveReference("refs/heads/master"))
+
+	_, err := repo.CreateRemote(&config.RemoteConfig{Name: "fork", URLs: []string{"https://github.com/someone/repo.git"}})
+	is.NoErr(err)
+
+	_, err = getRepoForDir(logger.New(logger.ErrorLevel), dir, dir, nil, nil)
+	is.True(err != nil)
+
+	is.NoErr(repo.Storer.SetReference(plumbing.NewSymbolicReference("refs/remotes/fork/HEAD", "refs/remotes/fork/trunk")))
+
+	ri, err := getRepoForDir(logger.New(logger.ErrorLevel), dir, dir, nil, nil)
+	is.NoErr(err)
+	is.Equal(ri.Remote, "https://github.com/someone/repo")
+	is.Equal(ri.DefaultBranch, "trunk")
+}
@@ -0,0 +1,83 @@
+package lang
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// deprecationPrefix starts the paragraph of a doc comment which marks a symbol
+// as deprecated, following the convention described at
+// https://go.dev/wiki/Deprecated.
+const deprecationPrefix = "Deprecated:"
+
+// splitDeprecation separates the deprecation paragraph from the rest of the
+// provided doc comment text. The note holds the text of the paragraph without
+// the "Deprecated:" prefix, joined into a single line. The rest of the text is
+// returned unchanged if the comment has no deprecation paragraph.
+func splitDeprecation(text string) (rest string, note string, deprecated bool) {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+
+	start := -1
+	for i, line := range lines {
+		// Only the first line of a paragraph can start a deprecation notice.
+		// Indented lines belong to code blocks or lists.
+		if (i == 0 || lines[i-1] == "") && strings.HasPrefix(line, deprecationPrefix) {
+			start = i
+			break
+		}
+	}
+
+	if start == -1 {
+		return text, "", false
+	}
+
+	end := start
+	for end < len(lines) && lines[end] != "" {
+		end++
+	}
+
+	noteLines := make([]string, end-start)
+	for i, line := range lines[start:end] {
+		noteLines[i] = strings.TrimSpace(line)
+	}
+
+	note = strings.TrimSpace(strings.TrimPrefix(strings.Join(noteLines, " "), deprecationPrefix))
+
+	// Remove the blank line separating the paragraph from the next one, or
+	// from the previous one if it is the last paragraph.
+	if end < len(lines) {
+		end++
+	} else if start > 0 {
+		start--
+	}
+
+	restLines := append(append([]string{}, lines[:start]...), lines[end:]...)
+
+	return strings.Join(restLines, "\n"), note, true
+}
+
+// withoutDeprecation provides the doc comment text without its deprecation
+// paragraph, which is rendered separately.
+func withoutDeprecation(text string) string {
+	rest, _, _ := splitDeprecation(text)
+	return rest
+}
+
+// deprecationNote provides the deprecation note of the doc comment text.
+func deprecationNote(text string) string {
+	_, note, _ := splitDeprecation(text)
+	return note
+}
+
+// isDeprecated reports whether the provided doc comment text contains a
+// deprecation paragraph.
+func isDeprecated(text string) bool {
+	_, _, deprecated := splitDeprecation(text)
+	return deprecated
+}
+
+// isDeprecatedField reports whether the doc comment of the struct field or
+// interface element contains a deprecation paragraph.
+func isDeprecatedField(field *ast.Field) bool {
+	return isDeprecated(field.Doc.Text())
+}
@@ -0,0 +1,53 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSplitDeprecation(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		rest       string
+		note       string
+		deprecated bool
+	}{
+		{"none", "Foo does things.\n", "Foo does things.\n", "", false},
+		{"only", "Deprecated: use Bar instead.\n", "", "use Bar instead.", true},
+		{
+			"last",
+			"Foo does things.\n\nDeprecated: use Bar\ninstead.\n",
+			"Foo does things.",
+			"use Bar instead.",
+			true,
+		},
+		{
+			"middle",
+			"Foo does things.\n\nDeprecated: use Bar.\n\nMore details.\n",
+			"Foo does things.\n\nMore details.",
+			"use Bar.",
+			true,
+		},
+		{
+			"not a paragraph start",
+			"Foo does things.\nDeprecated: is part of the sentence.\n",
+			"Foo does things.\nDeprecated: is part of the sentence.\n",
+			"",
+			false,
+		},
+		{"indented", "Foo does things.\n\n\tDeprecated: code\n", "Foo does things.\n\n\tDeprecated: code\n", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+
+			rest, note, deprecated := splitDeprecation(test.text)
+			is.Equal(rest, test.rest)
+			is.Equal(note, test.note)
+			is.Equal(deprecated, test.deprecated)
+		})
+	}
+}
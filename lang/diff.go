@@ -0,0 +1,290 @@
+package lang
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChangeKind describes how a package, type or field changed between two
+// versions of a package.
+type ChangeKind string
+
+const (
+	// Added marks a symbol which only exists in the newer version.
+	Added ChangeKind = "added"
+
+	// Removed marks a symbol which only exists in the older version.
+	Removed ChangeKind = "removed"
+
+	// Changed marks a symbol which exists in both versions but differs
+	// between them.
+	Changed ChangeKind = "changed"
+)
+
+type (
+	// APIDiff holds information for rendering a report of the changes to the
+	// documented API of one or more packages between two revisions.
+	APIDiff struct {
+		Header   string
+		Footer   string
+		From     string
+		To       string
+		Packages []*PackageDiff
+	}
+
+	// PackageDiff holds the changes to a single package. The types are listed
+	// alphabetically.
+	PackageDiff struct {
+		ImportPath string
+		Kind       ChangeKind
+		DocChanged bool
+		Types      []*TypeDiff
+	}
+
+	// TypeDiff holds the changes to a single type. The declarations are only
+	// set if the declaration changed in a way which is not described by the
+	// changes to the fields of a struct type.
+	TypeDiff struct {
+		Name        string
+		Kind        ChangeKind
+		DocChanged  bool
+		DeclChanged bool
+		OldDecl     string
+		NewDecl     string
+		Fields      []*FieldDiff
+	}
+
+	// FieldDiff holds the changes to a single field of a struct type.
+	// Embedded fields are named after their type. The type and tag of the
+	// field are provided for both versions, and are empty for the version
+	// which doesn't have the field.
+	FieldDiff struct {
+		Name       string
+		Kind       ChangeKind
+		DocChanged bool
+		OldType    string
+		NewType    string
+		OldTag     string
+		NewTag     string
+	}
+)
+
+// NewAPIDiff creates a new instance of APIDiff with the provided information.
+func NewAPIDiff(header, footer, from, to string, packages []*PackageDiff) *APIDiff {
+	return &APIDiff{
+		Header:   header,
+		Footer:   footer,
+		From:     from,
+		To:       to,
+		Packages: packages,
+	}
+}
+
+// TypeChanged reports whether the type of the field changed.
+func (f *FieldDiff) TypeChanged() bool {
+	return f.Kind == Changed && f.OldType != f.NewType
+}
+
+// TagChanged reports whether the struct tag of the field changed.
+func (f *FieldDiff) TagChanged() bool {
+	return f.Kind == Changed && f.OldTag != f.NewTag
+}
+
+// DiffPackages compares two versions of a package. Either of them may be nil
+// if the package only exists in one of the versions. Nil is returned if the
+// documented API of the package didn't change.
+func DiffPackages(oldPkg, newPkg *Package) *PackageDiff {
+	switch {
+	case oldPkg == nil && newPkg == nil:
+		return nil
+	case oldPkg == nil:
+		return &PackageDiff{ImportPath: newPkg.ImportPath(), Kind: Added}
+	case newPkg == nil:
+		return &PackageDiff{ImportPath: oldPkg.ImportPath(), Kind: Removed}
+	}
+
+	diff := &PackageDiff{
+		ImportPath: newPkg.ImportPath(),
+		Kind:       Changed,
+		DocChanged: docChanged(oldPkg.doc.Doc, newPkg.doc.Doc),
+	}
+
+	oldTypes := make(map[string]*Type)
+	for _, typ := range oldPkg.Types() {
+		oldTypes[typ.Name()] = typ
+	}
+
+	newTypes := make(map[string]*Type)
+	for _, typ := range newPkg.Types() {
+		newTypes[typ.Name()] = typ
+	}
+
+	for _, name := range unionKeys(oldTypes, newTypes) {
+		if typeDiff := diffTypes(name, oldTypes[name], newTypes[name]); typeDiff != nil {
+			diff.Types = append(diff.Types, typeDiff)
+		}
+	}
+
+	if !diff.DocChanged && len(diff.Types) == 0 {
+		return nil
+	}
+
+	return diff
+}
+
+func diffTypes(name string, oldType, newType *Type) *TypeDiff {
+	switch {
+	case oldType == nil:
+		return &TypeDiff{Name: name, Kind: Added}
+	case newType == nil:
+		return &TypeDiff{Name: name, Kind: Removed}
+	}
+
+	diff := &TypeDiff{
+		Name:       name,
+		Kind:       Changed,
+		DocChanged: docChanged(oldType.doc.Doc, newType.doc.Doc),
+	}
+
+	if oldType.IsStructType() && newType.IsStructType() {
+		diff.Fields = diffFields(oldType, newType)
+	} else {
+		// Declarations which fail to print are compared as empty, which
+		// reports them as unchanged.
+		oldDecl, _ := oldType.Decl()
+		newDecl, _ := newType.Decl()
+		if oldDecl != newDecl {
+			diff.DeclChanged = true
+			diff.OldDecl = oldDecl
+			diff.NewDecl = newDecl
+		}
+	}
+
+	if !diff.DocChanged && !diff.DeclChanged && len(diff.Fields) == 0 {
+		return nil
+	}
+
+	return diff
+}
+
+// diffFields compares the fields of two versions of a struct type. Fields are
+// listed in the order of the newer version, followed by the removed fields in
+// the order of the older version.
+func diffFields(oldType, newType *Type) []*FieldDiff {
+	oldFields, oldNames := structFields(oldType)
+	newFields, newNames := structFields(newType)
+
+	var diffs []*FieldDiff
+	for _, name := range newNames {
+		newField := newFields[name]
+		oldField, ok := oldFields[name]
+		if !ok {
+			diffs = append(diffs, &FieldDiff{
+				Name:    name,
+				Kind:    Added,
+				NewType: newField.typ,
+				NewTag:  newField.tag,
+			})
+			continue
+		}
+
+		diff := &FieldDiff{
+			Name:       name,
+			Kind:       Changed,
+			DocChanged: docChanged(oldField.doc, newField.doc),
+			OldType:    oldField.typ,
+			NewType:    newField.typ,
+			OldTag:     oldField.tag,
+			NewTag:     newField.tag,
+		}
+
+		if diff.DocChanged || diff.TypeChanged() || diff.TagChanged() {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	for _, name := range oldNames {
+		if _, ok := newFields[name]; ok {
+			continue
+		}
+
+		oldField := oldFields[name]
+		diffs = append(diffs, &FieldDiff{
+			Name:    name,
+			Kind:    Removed,
+			OldType: oldField.typ,
+			OldTag:  oldField.tag,
+		})
+	}
+
+	return diffs
+}
+
+// structField holds the properties of a struct field which are compared by
+// diffFields.
+type structField struct {
+	typ string
+	tag string
+	doc string
+}
+
+// structFields provides the documented fields of a struct type by name along
+// with their names in declaration order. A declaration of several names
+// provides a field for each of them.
+func structFields(typ *Type) (map[string]structField, []string) {
+	fields := make(map[string]structField)
+	var names []string
+	for _, f := range typ.getStructFields() {
+		// Types which fail to print are compared as empty.
+		fieldType, _ := printNode(f.Type, typ.cfg.FileSet)
+
+		var tag string
+		if f.Tag != nil {
+			if unquoted, err := strconv.Unquote(f.Tag.Value); err == nil {
+				tag = unquoted
+			} else {
+				tag = f.Tag.Value
+			}
+		}
+
+		field := structField{typ: fieldType, tag: tag, doc: f.Doc.Text()}
+
+		fieldNames := make([]string, len(f.Names))
+		for i, n := range f.Names {
+			fieldNames[i] = n.Name
+		}
+
+		if len(fieldNames) == 0 {
+			fieldNames = []string{fieldName(f)}
+		}
+
+		for _, name := range fieldNames {
+			fields[name] = field
+			names = append(names, name)
+		}
+	}
+
+	return fields, names
+}
+
+func docChanged(oldDoc, newDoc string) bool {
+	return strings.TrimSpace(oldDoc) != strings.TrimSpace(newDoc)
+}
+
+// unionKeys provides the keys of both maps in alphabetical order.
+func unionKeys(a, b map[string]*Type) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,81 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/cloudogu/gomarkdoc/lang"
+)
+
+func TestDiffPackages(t *testing.T) {
+	is := is.New(t)
+
+	oldPkg, err := loadPackage("../testData/lang/diff/old")
+	is.NoErr(err)
+
+	newPkg, err := loadPackage("../testData/lang/diff/new")
+	is.NoErr(err)
+
+	diff := lang.DiffPackages(oldPkg, newPkg)
+	is.True(diff != nil)
+	is.Equal(diff.Kind, lang.Changed)
+	is.True(diff.DocChanged)
+
+	var names []string
+	types := make(map[string]*lang.TypeDiff)
+	for _, typ := range diff.Types {
+		names = append(names, typ.Name)
+		types[typ.Name] = typ
+	}
+	is.Equal(names, []string{"Added", "Legacy", "Level", "Settings"}) // Config is unchanged
+
+	is.Equal(types["Added"].Kind, lang.Added)
+	is.Equal(types["Legacy"].Kind, lang.Removed)
+
+	level := types["Level"]
+	is.Equal(level.Kind, lang.Changed)
+	is.True(level.DeclChanged)
+	is.Equal(level.OldDecl, "type Level int")
+	is.Equal(level.NewDecl, "type Level string")
+
+	settings := types["Settings"]
+	is.True(settings.DocChanged)
+	is.True(!settings.DeclChanged)
+
+	fields := make([]lang.FieldDiff, len(settings.Fields))
+	for i, f := range settings.Fields {
+		fields[i] = *f
+	}
+
+	is.Equal(fields, []lang.FieldDiff{
+		{
+			Name:       "Timeout",
+			Kind:       lang.Changed,
+			DocChanged: true,
+			OldType:    "int",
+			NewType:    "time.Duration",
+			NewTag:     `json:"timeout"`,
+		},
+		{Name: "Debug", Kind: lang.Changed, OldType: "bool", NewType: "bool", OldTag: `env:"DEBUG"`},
+		{Name: "Verbose", Kind: lang.Changed, OldType: "bool", NewType: "bool", OldTag: `env:"DEBUG"`},
+		{Name: "Address", Kind: lang.Added, NewType: "string", NewTag: `json:"address"`},
+		{Name: "Location", Kind: lang.Added, NewType: "time.Location"},
+		{Name: "Port", Kind: lang.Removed, OldType: "int", OldTag: `json:"port"`},
+	})
+
+	is.True(fields[0].TypeChanged())
+	is.True(fields[0].TagChanged())
+	is.True(!fields[1].TypeChanged())
+}
+
+func TestDiffPackages_addedAndRemoved(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/lang/diff/new")
+	is.NoErr(err)
+
+	is.Equal(lang.DiffPackages(pkg, nil).Kind, lang.Removed)
+	is.Equal(lang.DiffPackages(nil, pkg).Kind, lang.Added)
+	is.Equal(lang.DiffPackages(pkg, pkg), nil)
+}
@@ -0,0 +1,198 @@
+package lang
+
+import (
+	"go/ast"
+	"go/doc"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// directivePrefix starts the comment lines which customize the documentation
+// of a symbol, such as //gomarkdoc:hide. Like other directives, these lines
+// are not part of the documentation text.
+const directivePrefix = "//gomarkdoc:"
+
+// directives holds the gomarkdoc directives found in the doc comment of a
+// type, func, value, struct field or interface method.
+type directives struct {
+	// hide omits the symbol from the documentation (//gomarkdoc:hide).
+	hide bool
+
+	// title replaces the title of the symbol (//gomarkdoc:title "Title").
+	title string
+
+	// group names the section the symbol is rendered in (//gomarkdoc:group
+	// name).
+	group string
+}
+
+// parseDirectives reads the directives from the provided comment groups.
+// Unknown directives are ignored. If a directive occurs multiple times, the
+// last occurrence wins.
+func parseDirectives(groups ...*ast.CommentGroup) directives {
+	var d directives
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+
+		for _, c := range group.List {
+			if !strings.HasPrefix(c.Text, directivePrefix) {
+				continue
+			}
+
+			name, arg, _ := strings.Cut(strings.TrimPrefix(c.Text, directivePrefix), " ")
+			switch name {
+			case "hide":
+				d.hide = true
+			case "title":
+				d.title = directiveArg(arg)
+			case "group":
+				d.group = directiveArg(arg)
+			}
+		}
+	}
+
+	return d
+}
+
+// directiveArg provides the argument of a directive, which may optionally be
+// quoted to include leading or trailing spaces.
+func directiveArg(arg string) string {
+	arg = strings.TrimSpace(arg)
+	if unquoted, err := strconv.Unquote(arg); err == nil {
+		return unquoted
+	}
+
+	return arg
+}
+
+// collectDirectives records the directives of the type, func and value
+// declarations in the provided files. This has to happen before the files are
+// processed by the doc package, which removes the doc comments of declarations
+// from the AST. The directives are keyed by the *ast.TypeSpec of types and
+// the *ast.FuncDecl or *ast.GenDecl of funcs and values, which are still
+// referenced by the documentation constructs of the doc package.
+func collectDirectives(files map[string]*ast.File) map[ast.Node]directives {
+	res := make(map[ast.Node]directives)
+	add := func(node ast.Node, groups ...*ast.CommentGroup) {
+		if d := parseDirectives(groups...); d != (directives{}) {
+			res[node] = d
+		}
+	}
+
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				add(d, d.Doc)
+			case *ast.GenDecl:
+				if d.Tok != token.TYPE {
+					add(d, d.Doc)
+					continue
+				}
+
+				for _, spec := range d.Specs {
+					if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+						// Like the doc package, fall back to the doc comment
+						// of the declaration if the spec has none.
+						if typeSpec.Doc != nil {
+							add(typeSpec, typeSpec.Doc)
+						} else {
+							add(typeSpec, d.Doc)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+// directivesOf provides the directives of a declaration. They are taken from
+// the directives collected for the package if available, and otherwise from
+// the doc comment still attached to the declaration, which is the case if the
+// doc package was used with the PreserveAST mode.
+func (c *Config) directivesOf(node ast.Node, doc *ast.CommentGroup) directives {
+	if d, ok := c.directives[node]; ok {
+		return d
+	}
+
+	return parseDirectives(doc)
+}
+
+// typeDirectives provides the directives of the type declared by the provided
+// declaration.
+func (c *Config) typeDirectives(decl *ast.GenDecl) directives {
+	if decl == nil {
+		return directives{}
+	}
+
+	for _, spec := range decl.Specs {
+		if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+			if typeSpec.Doc != nil {
+				return c.directivesOf(typeSpec, typeSpec.Doc)
+			}
+
+			return c.directivesOf(typeSpec, decl.Doc)
+		}
+	}
+
+	return directives{}
+}
+
+// omitField reports whether the struct field or interface element is left
+// out of the documentation, either because it is hidden or because it is
+// deprecated and deprecated symbols are excluded.
+func (c *Config) omitField(field *ast.Field) bool {
+	return parseDirectives(field.Doc).hide || (c.excludeDeprecated && isDeprecatedField(field))
+}
+
+// filterFields removes the struct fields or interface elements which are left
+// out of the documentation.
+func (c *Config) filterFields(fields []*ast.Field) []*ast.Field {
+	var kept []*ast.Field
+	for _, f := range fields {
+		if !c.omitField(f) {
+			kept = append(kept, f)
+		}
+	}
+
+	return kept
+}
+
+// omitType reports whether the type is left out of the documentation.
+func (c *Config) omitType(typ *doc.Type) bool {
+	return c.typeDirectives(typ.Decl).hide || (c.excludeDeprecated && isDeprecated(typ.Doc))
+}
+
+// omitFunc reports whether the func is left out of the documentation.
+func (c *Config) omitFunc(fn *doc.Func) bool {
+	return c.funcDirectives(fn).hide || (c.excludeDeprecated && isDeprecated(fn.Doc))
+}
+
+// omitValue reports whether the const or var declaration is left out of the
+// documentation.
+func (c *Config) omitValue(v *doc.Value) bool {
+	return c.valueDirectives(v).hide || (c.excludeDeprecated && isDeprecated(v.Doc))
+}
+
+// funcDirectives provides the directives of the func.
+func (c *Config) funcDirectives(fn *doc.Func) directives {
+	if fn.Decl == nil {
+		return directives{}
+	}
+
+	return c.directivesOf(fn.Decl, fn.Decl.Doc)
+}
+
+// valueDirectives provides the directives of the const or var declaration.
+func (c *Config) valueDirectives(v *doc.Value) directives {
+	if v.Decl == nil {
+		return directives{}
+	}
+
+	return c.directivesOf(v.Decl, v.Decl.Doc)
+}
@@ -0,0 +1,38 @@
+package lang
+
+import (
+	"go/ast"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParseDirectives(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  directives
+	}{
+		{"none", []string{"// Foo does things."}, directives{}},
+		{"hide", []string{"// Foo does things.", "//", "//gomarkdoc:hide"}, directives{hide: true}},
+		{"quoted title", []string{`//gomarkdoc:title "The Foo "`}, directives{title: "The Foo "}},
+		{"unquoted title", []string{"//gomarkdoc:title  The Foo "}, directives{title: "The Foo"}},
+		{"group", []string{"//gomarkdoc:group Network"}, directives{group: "Network"}},
+		{"last wins", []string{"//gomarkdoc:group a", "//gomarkdoc:group b"}, directives{group: "b"}},
+		{"unknown", []string{"//gomarkdoc:unknown", "//go:generate true"}, directives{}},
+		{"not a directive", []string{"// gomarkdoc:hide"}, directives{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+
+			var group ast.CommentGroup
+			for _, line := range test.lines {
+				group.List = append(group.List, &ast.Comment{Text: line})
+			}
+
+			is.Equal(parseDirectives(&group), test.want)
+		})
+	}
+}
@@ -19,7 +19,7 @@ type Doc struct {
 // is separated into block level elements using the standard rules from golang's
 // documentation conventions.
 func NewDoc(cfg *Config, text string) *Doc {
-	return NewDocWithDocLinkParser(cfg, text, currentPackage, knownTypes)
+	return NewDocWithDocLinkParser(cfg, text, cfg.pkgName, cfg.pkgTypes)
 }
 
 // NewDocWithDocLinkParser initializes a Doc struct with additional information for modifying [comment.Parser].
@@ -28,6 +28,10 @@ func NewDocWithDocLinkParser(cfg *Config, text string, currentPackage string, ty
 	// Replace CRLF with LF
 	rawText := normalizeDoc(text)
 
+	cfg = cfg.Inc(0)
+	cfg.pkgName = currentPackage
+	cfg.pkgTypes = types
+
 	doc := Doc{cfg, nil, currentPackage, types}
 	var p comment.Parser
 	p.LookupPackage = doc.lookUpPackage
@@ -0,0 +1,112 @@
+package lang
+
+import "strings"
+
+// docExamplePrefix starts the paragraph of a doc comment which introduces an
+// example written as a code block within the comment itself.
+const docExamplePrefix = "Example:"
+
+// docExample holds an example written in a doc comment. The doc holds the text
+// of the introducing paragraph without the "Example:" prefix.
+type docExample struct {
+	doc  string
+	code string
+}
+
+// splitDocExamples separates the examples from the rest of the provided doc
+// comment text. An example is a paragraph starting with "Example:" followed by
+// a code block. Paragraphs starting with "Example:" which aren't followed by a
+// code block are left in place.
+func splitDocExamples(text string) (rest string, examples []docExample) {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+
+	var restLines []string
+	for i := 0; i < len(lines); {
+		// Only the first line of a paragraph can start an example.
+		if (i == 0 || lines[i-1] == "") && strings.HasPrefix(lines[i], docExamplePrefix) {
+			if ex, end, ok := parseDocExample(lines, i); ok {
+				examples = append(examples, ex)
+				i = end
+				continue
+			}
+		}
+
+		restLines = append(restLines, lines[i])
+		i++
+	}
+
+	if len(examples) == 0 {
+		return text, nil
+	}
+
+	return strings.TrimRight(strings.Join(restLines, "\n"), "\n"), examples
+}
+
+// withoutDocExamples provides the doc comment text without its examples,
+// which are rendered separately.
+func withoutDocExamples(text string) string {
+	rest, _ := splitDocExamples(text)
+	return rest
+}
+
+// parseDocExample parses the example starting at the provided line. The end is
+// the index of the first line after the code block and the blank lines
+// following it.
+func parseDocExample(lines []string, start int) (ex docExample, end int, ok bool) {
+	end = start
+	var paragraph []string
+	for end < len(lines) && lines[end] != "" && !isIndented(lines[end]) {
+		paragraph = append(paragraph, strings.TrimSpace(lines[end]))
+		end++
+	}
+
+	for end < len(lines) && lines[end] == "" {
+		end++
+	}
+
+	// Blank lines within the code block belong to it, while the ones after it
+	// are skipped along with it.
+	codeStart, codeEnd := end, end
+	for end < len(lines) && (lines[end] == "" || isIndented(lines[end])) {
+		end++
+		if lines[end-1] != "" {
+			codeEnd = end
+		}
+	}
+
+	if codeEnd == codeStart {
+		return docExample{}, 0, false
+	}
+
+	return docExample{
+		doc:  strings.TrimSpace(strings.TrimPrefix(strings.Join(paragraph, " "), docExamplePrefix)),
+		code: unindent(lines[codeStart:codeEnd]),
+	}, end, true
+}
+
+func isIndented(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+}
+
+// unindent removes the indentation of the least indented line from all of
+// the lines.
+func unindent(lines []string) string {
+	var indent string
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		lineIndent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if i == 0 || len(lineIndent) < len(indent) {
+			indent = lineIndent
+		}
+	}
+
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		result[i] = strings.TrimPrefix(line, indent)
+	}
+
+	return strings.Join(result, "\n")
+}
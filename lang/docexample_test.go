@@ -0,0 +1,58 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSplitDocExamples(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		rest     string
+		examples []docExample
+	}{
+		{"none", "Foo does things.\n", "Foo does things.\n", nil},
+		{
+			"last",
+			"Foo does things.\n\nExample:\n\n\tfoo: 1\n\tbar:\n\t  baz: 2\n",
+			"Foo does things.",
+			[]docExample{{"", "foo: 1\nbar:\n  baz: 2"}},
+		},
+		{
+			"middle with description",
+			"Foo does things.\n\nExample: with\nenv vars.\n\n\tFOO=1\n\n\tBAR=2\n\nMore details.\n",
+			"Foo does things.\n\nMore details.",
+			[]docExample{{"with env vars.", "FOO=1\n\nBAR=2"}},
+		},
+		{
+			"several",
+			"Example:\n\n\tfoo: 1\n\nExample: bar\n\n\tbar: 2\n",
+			"",
+			[]docExample{{"", "foo: 1"}, {"bar", "bar: 2"}},
+		},
+		{
+			"without code",
+			"Foo does things.\n\nExample: none.\n\nMore details.\n",
+			"Foo does things.\n\nExample: none.\n\nMore details.\n",
+			nil,
+		},
+		{
+			"not a paragraph start",
+			"Foo does things.\nExample: part of the sentence.\n\n\tcode\n",
+			"Foo does things.\nExample: part of the sentence.\n\n\tcode\n",
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+
+			rest, examples := splitDocExamples(test.text)
+			is.Equal(rest, test.rest)
+			is.Equal(examples, test.examples)
+		})
+	}
+}
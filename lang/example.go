@@ -1,7 +1,7 @@
 package lang
 
 import (
-	"fmt"
+	"go/ast"
 	"go/doc"
 	"go/format"
 	"strings"
@@ -12,12 +12,23 @@ type Example struct {
 	cfg  *Config
 	name string
 	doc  *doc.Example
+
+	// node and code are only set for examples written in a doc comment, whose
+	// code is kept as text. The node is the documented declaration.
+	node ast.Node
+	code string
 }
 
 // NewExample creates a new example from the example function's name, its
 // documentation example and the files holding code related to the example.
 func NewExample(cfg *Config, name string, doc *doc.Example) *Example {
-	return &Example{cfg, name, doc}
+	return &Example{cfg: cfg, name: name, doc: doc}
+}
+
+// newDocExample creates a new example from an example written in the doc
+// comment of the provided node.
+func newDocExample(cfg *Config, node ast.Node, ex docExample) *Example {
+	return &Example{cfg: cfg, doc: &doc.Example{Doc: ex.doc}, node: node, code: ex.code}
 }
 
 // Level provides the default level that headers for the example should be
@@ -37,15 +48,19 @@ func (ex *Example) Name() string {
 func (ex *Example) Title() string {
 	name := ex.Name()
 	if name == "" {
-		return "Example"
+		return ex.cfg.catalog.Translate("Example")
 	}
 
-	return fmt.Sprintf("Example (%s)", name)
+	return ex.cfg.catalog.Translate("Example (%s)", name)
 }
 
 // Location returns a representation of the node's location in a file within a
 // repository.
 func (ex *Example) Location() Location {
+	if ex.node != nil {
+		return NewLocation(ex.cfg, ex.node)
+	}
+
 	return NewLocation(ex.cfg, ex.doc.Code)
 }
 
@@ -63,6 +78,10 @@ func (ex *Example) Doc() *Doc {
 
 // Code provides the raw text code representation of the example's contents.
 func (ex *Example) Code() (string, error) {
+	if ex.node != nil {
+		return ex.code, nil
+	}
+
 	var codeNode interface{}
 	if ex.doc.Play != nil {
 		codeNode = ex.doc.Play
@@ -78,6 +97,16 @@ func (ex *Example) Code() (string, error) {
 	return code.String(), nil
 }
 
+// Language provides the language of the example's code for highlighting. The
+// language of examples written in doc comments is unknown, so it is empty.
+func (ex *Example) Language() string {
+	if ex.node != nil {
+		return ""
+	}
+
+	return "go"
+}
+
 // Output provides the code's example output.
 func (ex *Example) Output() string {
 	return ex.doc.Output
@@ -11,15 +11,25 @@ import (
 // type.
 type Field struct {
 	cfg      *Config
+	typeName string
 	doc      *ast.Field
 	examples []*doc.Example
 }
 
 // NewField creates a new Field from the corresponding documentation construct
-// from the standard library, the related token.FileSet for the field and
-// the list of examples for the field.
+// from the standard library and the list of examples for the field. Example
+// functions are matched by the name of the field, such as ExampleName or
+// ExampleName_suffix. Use Type.Fields to match them by the names of the type
+// and the field instead.
 func NewField(cfg *Config, doc *ast.Field, examples []*doc.Example) *Field {
-	return &Field{cfg, doc, examples}
+	return &Field{cfg: cfg, doc: doc, examples: examples}
+}
+
+// newTypeField creates a new Field declared by the struct type with the
+// provided name. The name is needed to find the example functions of the field
+// among the examples of the type.
+func newTypeField(cfg *Config, typeName string, doc *ast.Field, examples []*doc.Example) *Field {
+	return &Field{cfg, typeName, doc, examples}
 }
 
 // Level provides the default level at which headers for the field should be
@@ -34,21 +44,58 @@ func (f *Field) Name() string {
 }
 
 // Title provides the formatted name of the field. It is primarily designed for
-// generating headers.
+// generating headers. This is the custom title if one was set using the
+// //gomarkdoc:title directive.
 func (f *Field) Title() string {
-	return fmt.Sprintf("Field %s", f.Name())
+	if title := f.CustomTitle(); title != "" {
+		return title
+	}
+
+	return f.cfg.catalog.Translate("Field %s", f.Name())
+}
+
+// CustomTitle provides the title set for the field using the
+// //gomarkdoc:title directive, or an empty string if there is none.
+func (f *Field) CustomTitle() string {
+	return parseDirectives(f.doc.Doc).title
+}
+
+// Group provides the name of the group set for the field using the
+// //gomarkdoc:group directive, or an empty string if there is none. Fields are
+// rendered in sections per group, see Type.FieldGroups.
+func (f *Field) Group() string {
+	return parseDirectives(f.doc.Doc).group
 }
 
 // Summary provides the one-sentence summary of the field's documentation
 // comment
 func (f *Field) Summary() string {
-	return extractSummary(f.doc.Doc.Text())
+	return extractSummary(f.text())
 }
 
 // Doc provides the structured contents of the documentation comment for the
 // field.
 func (f *Field) Doc() *Doc {
-	return NewDoc(f.cfg.Inc(1), f.doc.Doc.Text())
+	return NewDoc(f.cfg.Inc(1), f.text())
+}
+
+// text provides the doc comment text of the field without the deprecation
+// paragraph and the examples, which are rendered separately.
+func (f *Field) text() string {
+	return withoutDocExamples(withoutDeprecation(f.doc.Doc.Text()))
+}
+
+// Deprecated reports whether the field is deprecated, i.e. its documentation
+// comment contains a paragraph starting with "Deprecated:".
+func (f *Field) Deprecated() bool {
+	return isDeprecated(f.doc.Doc.Text())
+}
+
+// DeprecationNote provides the text of the deprecation paragraph of the
+// field's documentation comment without the "Deprecated:" prefix. The
+// paragraph is not part of Doc, so that it can be rendered separately.
+func (f *Field) DeprecationNote() string {
+	return deprecationNote(f.doc.Doc.Text())
 }
 
 // Decl provides the raw text representation of the code for declaring the const
@@ -57,10 +104,23 @@ func (f *Field) Decl() (string, error) {
 	return printNode(f.doc, f.cfg.FileSet)
 }
 
-// Examples provides the list of examples from the list given on initialization
-// that pertain to the field.
+// Examples provides the examples of the field. These are the examples written
+// in the field's doc comment as a paragraph starting with "Example:" followed
+// by a code block, and the example functions named after the type and the
+// field, such as ExampleConfig_Name or ExampleConfig_Name_suffix. For fields
+// created with NewField, the example functions are named after the field only.
 func (f *Field) Examples() (examples []*Example) {
+	_, docExamples := splitDocExamples(withoutDeprecation(f.doc.Doc.Text()))
+	for _, ex := range docExamples {
+		examples = append(examples, newDocExample(f.cfg.Inc(1), f.doc, ex))
+	}
+
+	// Fields created with NewField don't know the type declaring them.
 	fullName := f.Name()
+	if f.typeName != "" {
+		fullName = fmt.Sprintf("%s_%s", f.typeName, f.Name())
+	}
+
 	underscorePrefix := fmt.Sprintf("%s_", fullName)
 
 	for _, example := range f.examples {
@@ -71,7 +131,6 @@ func (f *Field) Examples() (examples []*Example) {
 		case strings.HasPrefix(example.Name, underscorePrefix):
 			name = example.Name[len(underscorePrefix):]
 		default:
-			// TODO: better filtering
 			continue
 		}
 
@@ -80,3 +139,34 @@ func (f *Field) Examples() (examples []*Example) {
 
 	return
 }
+
+// FieldGroup holds the struct fields of a type which share the same group set
+// using the //gomarkdoc:group directive.
+type FieldGroup struct {
+	cfg    *Config
+	name   string
+	fields []*Field
+}
+
+// Level provides the default level at which the header for the group should be
+// rendered in the final documentation.
+func (g *FieldGroup) Level() int {
+	return g.cfg.Level
+}
+
+// Name provides the name of the group. The group of the fields without a
+// //gomarkdoc:group directive has an empty name.
+func (g *FieldGroup) Name() string {
+	return g.name
+}
+
+// Title provides the formatted name of the group. It is primarily designed for
+// generating headers.
+func (g *FieldGroup) Title() string {
+	return g.name
+}
+
+// Fields lists the fields of the group in declaration order.
+func (g *FieldGroup) Fields() []*Field {
+	return g.fields
+}
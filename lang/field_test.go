@@ -0,0 +1,34 @@
+package lang_test
+
+import (
+	"go/ast"
+	"go/doc"
+	"go/parser"
+	"testing"
+
+	"github.com/cloudogu/gomarkdoc/lang"
+	"github.com/matryer/is"
+)
+
+func TestNewField_Examples(t *testing.T) {
+	is := is.New(t)
+
+	expr, err := parser.ParseExpr("struct{ Name string }")
+	is.NoErr(err)
+
+	examples := []*doc.Example{
+		{Name: "Name"},
+		{Name: "Name_empty"},
+		{Name: "Settings_Name"},
+		{Name: "Other"},
+	}
+
+	field := lang.NewField(&lang.Config{Level: 1}, expr.(*ast.StructType).Fields.List[0], examples)
+
+	// Without the type declaring the field, examples are named after the
+	// field only.
+	ex := field.Examples()
+	is.Equal(len(ex), 2)
+	is.Equal(ex[0].Name(), "")
+	is.Equal(ex[1].Name(), "Empty")
+}
@@ -34,13 +34,30 @@ func (fn *Func) Name() string {
 }
 
 // Title provides the formatted name of the func. It is primarily designed for
-// generating headers.
+// generating headers. This is the custom title if one was set using the
+// //gomarkdoc:title directive.
 func (fn *Func) Title() string {
+	if title := fn.CustomTitle(); title != "" {
+		return title
+	}
+
 	if fn.doc.Recv != "" {
-		return fmt.Sprintf("func (%s) %s", fn.doc.Recv, fn.doc.Name)
+		return fn.cfg.catalog.Translate("func (%s) %s", fn.doc.Recv, fn.doc.Name)
 	}
 
-	return fmt.Sprintf("func %s", fn.doc.Name)
+	return fn.cfg.catalog.Translate("func %s", fn.doc.Name)
+}
+
+// CustomTitle provides the title set for the func using the //gomarkdoc:title
+// directive, or an empty string if there is none.
+func (fn *Func) CustomTitle() string {
+	return fn.cfg.funcDirectives(fn.doc).title
+}
+
+// Group provides the name of the group set for the func using the
+// //gomarkdoc:group directive, or an empty string if there is none.
+func (fn *Func) Group() string {
+	return fn.cfg.funcDirectives(fn.doc).group
 }
 
 // Receiver provides the type of the receiver for the function, or empty string
@@ -58,13 +75,26 @@ func (fn *Func) Location() Location {
 // Summary provides the one-sentence summary of the function's documentation
 // comment
 func (fn *Func) Summary() string {
-	return extractSummary(fn.doc.Doc)
+	return extractSummary(withoutDeprecation(fn.doc.Doc))
 }
 
 // Doc provides the structured contents of the documentation comment for the
 // function.
 func (fn *Func) Doc() *Doc {
-	return NewDoc(fn.cfg.Inc(1), fn.doc.Doc)
+	return NewDoc(fn.cfg.Inc(1), withoutDeprecation(fn.doc.Doc))
+}
+
+// Deprecated reports whether the function is deprecated, i.e. its documentation
+// comment contains a paragraph starting with "Deprecated:".
+func (fn *Func) Deprecated() bool {
+	return isDeprecated(fn.doc.Doc)
+}
+
+// DeprecationNote provides the text of the deprecation paragraph of the
+// function's documentation comment without the "Deprecated:" prefix. The
+// paragraph is not part of Doc, so that it can be rendered separately.
+func (fn *Func) DeprecationNote() string {
+	return deprecationNote(fn.doc.Doc)
 }
 
 // Signature provides the raw text representation of the code for the
@@ -0,0 +1,191 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/token"
+	"strings"
+)
+
+// InterfaceMethod holds documentation information for a single element of an
+// interface type, which is either a method, an embedded interface or a type
+// set constraint.
+type InterfaceMethod struct {
+	cfg      *Config
+	typeName string
+	doc      *ast.Field
+	examples []*doc.Example
+}
+
+// InterfaceMethodKind identifies the kind of an interface element.
+type InterfaceMethodKind string
+
+const (
+	// DeclaredMethod identifies a method declared by the interface.
+	DeclaredMethod InterfaceMethodKind = "method"
+
+	// EmbeddedInterface identifies an embedded interface, such as io.Reader.
+	// Since types are not resolved, a single named type in a constraint
+	// interface (e.g. interface{ MyInt }) is reported as embedded as well.
+	EmbeddedInterface InterfaceMethodKind = "embedded"
+
+	// TypeConstraint identifies a type set constraint, such as ~int | ~string.
+	TypeConstraint InterfaceMethodKind = "constraint"
+)
+
+// NewInterfaceMethod creates a new InterfaceMethod from the corresponding
+// element of the interface type with the provided name and the list of
+// examples for the type.
+func NewInterfaceMethod(cfg *Config, typeName string, doc *ast.Field, examples []*doc.Example) *InterfaceMethod {
+	return &InterfaceMethod{cfg, typeName, doc, examples}
+}
+
+// Level provides the default level at which headers for the interface element
+// should be rendered in the final documentation.
+func (m *InterfaceMethod) Level() int {
+	return m.cfg.Level
+}
+
+// Kind provides the kind of the interface element.
+func (m *InterfaceMethod) Kind() InterfaceMethodKind {
+	if len(m.doc.Names) > 0 {
+		return DeclaredMethod
+	}
+
+	expr := m.doc.Type
+	for {
+		paren, ok := expr.(*ast.ParenExpr)
+		if !ok {
+			break
+		}
+
+		expr = paren.X
+	}
+
+	switch expr.(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.IndexExpr, *ast.IndexListExpr:
+		return EmbeddedInterface
+	default:
+		return TypeConstraint
+	}
+}
+
+// Name provides the name of the method. For embedded interfaces and type set
+// constraints, the name is the embedded type or the constraint (e.g. ~int |
+// ~string).
+func (m *InterfaceMethod) Name() string {
+	if len(m.doc.Names) > 0 {
+		return m.doc.Names[0].Name
+	}
+
+	name, err := printNode(m.doc.Type, token.NewFileSet())
+	if err != nil {
+		return ""
+	}
+
+	return name
+}
+
+// Title provides the formatted name of the interface element. It is primarily
+// designed for generating headers. This is the custom title if one was set
+// using the //gomarkdoc:title directive.
+func (m *InterfaceMethod) Title() string {
+	if title := m.CustomTitle(); title != "" {
+		return title
+	}
+
+	switch m.Kind() {
+	case DeclaredMethod:
+		return m.cfg.catalog.Translate("Method %s", m.Name())
+	case EmbeddedInterface:
+		return m.cfg.catalog.Translate("Embedded %s", m.Name())
+	default:
+		return m.cfg.catalog.Translate("Constraint %s", m.Name())
+	}
+}
+
+// CustomTitle provides the title set for the interface element using the
+// //gomarkdoc:title directive, or an empty string if there is none.
+func (m *InterfaceMethod) CustomTitle() string {
+	return parseDirectives(m.doc.Doc).title
+}
+
+// Location returns a representation of the node's location in a file within a
+// repository.
+func (m *InterfaceMethod) Location() Location {
+	return NewLocation(m.cfg, m.doc)
+}
+
+// Summary provides the one-sentence summary of the interface element's
+// documentation comment.
+func (m *InterfaceMethod) Summary() string {
+	return extractSummary(withoutDeprecation(m.doc.Doc.Text()))
+}
+
+// Doc provides the structured contents of the documentation comment for the
+// interface element.
+func (m *InterfaceMethod) Doc() *Doc {
+	return NewDoc(m.cfg.Inc(1), withoutDeprecation(m.doc.Doc.Text()))
+}
+
+// Deprecated reports whether the interface element is deprecated, i.e. its
+// documentation comment contains a paragraph starting with "Deprecated:".
+func (m *InterfaceMethod) Deprecated() bool {
+	return isDeprecated(m.doc.Doc.Text())
+}
+
+// DeprecationNote provides the text of the deprecation paragraph of the
+// interface element's documentation comment without the "Deprecated:" prefix.
+// The paragraph is not part of Doc, so that it can be rendered separately.
+func (m *InterfaceMethod) DeprecationNote() string {
+	return deprecationNote(m.doc.Doc.Text())
+}
+
+// Signature provides the raw text representation of the code for the method's
+// signature, such as "func Read(p []byte) (n int, err error)". For embedded
+// interfaces and type set constraints, the embedded type or constraint is
+// provided.
+func (m *InterfaceMethod) Signature() (string, error) {
+	fnType, ok := m.doc.Type.(*ast.FuncType)
+	if !ok || len(m.doc.Names) == 0 {
+		return printNode(m.doc.Type, token.NewFileSet())
+	}
+
+	// We use a custom FileSet so that we don't inherit multiline formatting
+	return printNode(&ast.FuncDecl{Name: m.doc.Names[0], Type: fnType}, token.NewFileSet())
+}
+
+// Decl provides the raw text representation of the code for the element as it
+// is declared within the interface, without its documentation comment.
+func (m *InterfaceMethod) Decl() (string, error) {
+	return printNode(copyFieldWithoutDoc(m.doc), m.cfg.FileSet)
+}
+
+// Examples provides the list of examples from the list given on initialization
+// that pertain to the method, following the ExampleType_Method naming
+// convention. Embedded interfaces and constraints have no examples.
+func (m *InterfaceMethod) Examples() (examples []*Example) {
+	if m.Kind() != DeclaredMethod {
+		return nil
+	}
+
+	fullName := fmt.Sprintf("%s_%s", m.typeName, m.Name())
+	underscorePrefix := fmt.Sprintf("%s_", fullName)
+
+	for _, example := range m.examples {
+		var name string
+		switch {
+		case example.Name == fullName:
+			name = ""
+		case strings.HasPrefix(example.Name, underscorePrefix):
+			name = example.Name[len(underscorePrefix):]
+		default:
+			continue
+		}
+
+		examples = append(examples, NewExample(m.cfg.Inc(1), name, example))
+	}
+
+	return
+}
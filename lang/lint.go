@@ -0,0 +1,345 @@
+package lang
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// LintRule identifies a check performed by LintPackage.
+type LintRule string
+
+const (
+	// MissingDocRule reports packages, types and funcs without documentation.
+	MissingDocRule LintRule = "missing-doc"
+
+	// MissingFieldDocRule reports struct fields without documentation.
+	MissingFieldDocRule LintRule = "missing-field-doc"
+
+	// DocPrefixRule reports documentation of packages, types and funcs which
+	// doesn't start with the name of the symbol, such as "Package name" or
+	// "Name". The documentation of a type may start with an article.
+	DocPrefixRule LintRule = "doc-prefix"
+
+	// BrokenDocLinkRule reports references such as [Name] or [pkg.Name] which
+	// neither link to a symbol of the package nor to a package or URL.
+	BrokenDocLinkRule LintRule = "broken-doc-link"
+)
+
+// LintRules lists all rules checked by LintPackage.
+var LintRules = []LintRule{MissingDocRule, MissingFieldDocRule, DocPrefixRule, BrokenDocLinkRule}
+
+type (
+	// LintResult holds the problems found in the documentation of a package
+	// along with its documentation coverage. The coverage counts the package,
+	// its types, funcs, methods and struct fields.
+	LintResult struct {
+		ImportPath string
+		Problems   []*LintProblem
+		Symbols    int
+		Documented int
+	}
+
+	// LintProblem describes a single problem found in the documentation of a
+	// package. The symbol is the name of the type, func or field, qualified
+	// with the name of its type for methods and fields, or the import path
+	// for the package itself.
+	LintProblem struct {
+		Rule     LintRule
+		Symbol   string
+		Message  string
+		Location Location
+	}
+)
+
+// Coverage provides the percentage of the symbols of the package which are
+// documented. A package without symbols is fully covered.
+func (r *LintResult) Coverage() float64 {
+	if r.Symbols == 0 {
+		return 100
+	}
+
+	return float64(r.Documented) * 100 / float64(r.Symbols)
+}
+
+// LintPackage checks the documentation of the package for the problems
+// described by LintRules. Only the symbols which are part of the
+// documentation are checked, so unexported and hidden symbols are left out
+// unless the package was configured to include them.
+func LintPackage(pkg *Package) *LintResult {
+	l := &linter{
+		result:  &LintResult{ImportPath: pkg.ImportPath()},
+		symbols: packageSymbols(pkg),
+		imports: make(map[string]bool),
+	}
+
+	for _, imp := range pkg.doc.Imports {
+		l.imports[path.Base(imp)] = true
+	}
+
+	l.check(pkg.ImportPath(), "Package "+pkg.Name(), pkg.doc.Doc, false, packageDocLocation(pkg), pkg.Doc)
+
+	for _, fn := range pkg.Funcs() {
+		l.checkFunc(fn)
+	}
+
+	for _, typ := range pkg.Types() {
+		l.check(typ.Name(), typ.Name(), typ.doc.Doc, true, typ.Location(), typ.Doc)
+
+		for _, fn := range typ.Funcs() {
+			l.checkFunc(fn)
+		}
+
+		for _, fn := range typ.Methods() {
+			l.checkFunc(fn)
+		}
+
+		for _, f := range typ.getStructFields() {
+			// Embedded fields are documented by their type.
+			if len(f.Names) == 0 {
+				continue
+			}
+
+			field := newTypeField(typ.cfg.Inc(1), typ.Name(), f, nil)
+			symbol := fmt.Sprintf("%s.%s", typ.Name(), field.Name())
+			loc := NewLocation(typ.cfg, f)
+
+			l.result.Symbols++
+			if strings.TrimSpace(f.Doc.Text()) == "" {
+				l.report(MissingFieldDocRule, symbol, loc, "field %s has no documentation", symbol)
+				continue
+			}
+
+			l.result.Documented++
+			l.checkDocLinks(symbol, loc, field.Doc())
+		}
+	}
+
+	return l.result
+}
+
+// linter collects the problems of a package.
+type linter struct {
+	result  *LintResult
+	symbols map[string]bool
+	imports map[string]bool
+}
+
+func (l *linter) checkFunc(fn *Func) {
+	symbol := fn.Name()
+	if recv := fn.rawRecv(); recv != "" {
+		symbol = fmt.Sprintf("%s.%s", strings.TrimPrefix(recv, "*"), fn.Name())
+	}
+
+	l.check(symbol, fn.Name(), fn.doc.Doc, false, fn.Location(), fn.Doc)
+}
+
+// check checks the documentation of a package, type or func, which should
+// start with the provided prefix. Types may start with an article instead.
+func (l *linter) check(symbol, prefix, text string, article bool, loc Location, doc func() *Doc) {
+	l.result.Symbols++
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		l.report(MissingDocRule, symbol, loc, "%s has no documentation", symbol)
+		return
+	}
+
+	l.result.Documented++
+
+	if !hasDocPrefix(text, prefix, article) {
+		l.report(DocPrefixRule, symbol, loc, "documentation of %s should start with %q", symbol, prefix)
+	}
+
+	l.checkDocLinks(symbol, loc, doc())
+}
+
+func (l *linter) checkDocLinks(symbol string, loc Location, doc *Doc) {
+	for _, ref := range unresolvedRefs(doc.Blocks()) {
+		if !l.resolves(ref) {
+			l.report(BrokenDocLinkRule, symbol, loc, "documentation of %s has a broken doc link [%s]", symbol, ref)
+		}
+	}
+}
+
+// resolves reports whether the reference refers to a symbol of the package.
+// The symbols of imported packages are not known, so references qualified
+// with the name of an imported package are accepted as well.
+func (l *linter) resolves(ref string) bool {
+	if l.symbols[ref] {
+		return true
+	}
+
+	qualifier, _, ok := strings.Cut(ref, ".")
+	return ok && l.imports[qualifier]
+}
+
+func (l *linter) report(rule LintRule, symbol string, loc Location, format string, args ...any) {
+	l.result.Problems = append(l.result.Problems, &LintProblem{
+		Rule:     rule,
+		Symbol:   symbol,
+		Message:  fmt.Sprintf(format, args...),
+		Location: loc,
+	})
+}
+
+// hasDocPrefix reports whether the documentation starts with the prefix as a
+// whole word, optionally preceded by an article.
+func hasDocPrefix(text, prefix string, article bool) bool {
+	candidates := []string{text}
+	if article {
+		for _, a := range []string{"A ", "An ", "The "} {
+			if strings.HasPrefix(text, a) {
+				candidates = append(candidates, text[len(a):])
+			}
+		}
+	}
+
+	for _, c := range candidates {
+		if c == prefix {
+			return true
+		}
+
+		if strings.HasPrefix(c, prefix) && !isIdentRune(rune(c[len(prefix)])) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
+
+// docRefRegex matches text between brackets which starts with a letter, as
+// used for doc links. References are only matched if they are not part of an
+// expression such as map[string]int, which is checked by unresolvedRefs.
+var docRefRegex = regexp.MustCompile(`\[([A-Za-z][^\[\]]*)\]`)
+
+// unresolvedRefs provides the references written as doc links which remained
+// plain text, since the parser could resolve them neither to a doc link nor to
+// a link definition.
+func unresolvedRefs(blocks []*Block) []string {
+	var refs []string
+	for _, b := range blocks {
+		switch b.Kind() {
+		case ParagraphBlock, CalloutBlock:
+			for _, s := range b.Spans() {
+				if s.Kind() != PlainSpan {
+					continue
+				}
+
+				text := s.Text()
+				for _, loc := range docRefRegex.FindAllStringSubmatchIndex(text, -1) {
+					if loc[0] > 0 && (isIdentRune(rune(text[loc[0]-1])) || text[loc[0]-1] == ']') {
+						continue
+					}
+
+					if loc[1] < len(text) && (isIdentRune(rune(text[loc[1]])) || text[loc[1]] == '(') {
+						continue
+					}
+
+					refs = append(refs, text[loc[2]:loc[3]])
+				}
+			}
+		case ListBlock:
+			for _, item := range b.List().Items() {
+				refs = append(refs, unresolvedRefs(item.Blocks())...)
+			}
+		}
+	}
+
+	return refs
+}
+
+// packageSymbols provides the names which doc links within the package may
+// refer to, which are the names of its types, funcs and values as well as the
+// methods and fields of its types in the form Type.Name. Each of them may be
+// qualified with the name of the package.
+func packageSymbols(pkg *Package) map[string]bool {
+	symbols := make(map[string]bool)
+	add := func(name string) {
+		symbols[name] = true
+		symbols[pkg.Name()+"."+name] = true
+	}
+
+	addValues := func(values []*Value) {
+		for _, v := range values {
+			for _, name := range v.doc.Names {
+				add(name)
+			}
+		}
+	}
+
+	addValues(pkg.Consts())
+	addValues(pkg.Vars())
+
+	for _, fn := range pkg.Funcs() {
+		add(fn.Name())
+	}
+
+	for _, typ := range pkg.Types() {
+		add(typ.Name())
+		addValues(typ.Consts())
+		addValues(typ.Vars())
+
+		for _, fn := range typ.Funcs() {
+			add(fn.Name())
+		}
+
+		for _, fn := range typ.Methods() {
+			add(typ.Name() + "." + fn.Name())
+		}
+
+		for _, f := range typ.getStructFields() {
+			for _, n := range f.Names {
+				add(typ.Name() + "." + n.Name)
+			}
+		}
+
+		for _, f := range typ.getInterfaceMethods() {
+			for _, n := range f.Names {
+				add(typ.Name() + "." + n.Name)
+			}
+		}
+	}
+
+	return symbols
+}
+
+// packageDocLocation provides the location of the package clause which holds
+// the documentation of the package. If no file documents the package, the
+// package clause of the first file is used.
+func packageDocLocation(pkg *Package) Location {
+	var first Location
+	for i, name := range pkg.doc.Filenames {
+		fs := token.NewFileSet()
+		f, err := parser.ParseFile(fs, name, nil, parser.PackageClauseOnly|parser.ParseComments)
+		if err != nil {
+			continue
+		}
+
+		start, end := fs.Position(f.Package), fs.Position(f.Name.End())
+		loc := Location{
+			Start:    Position{start.Line, start.Column},
+			End:      Position{end.Line, end.Column},
+			Filepath: start.Filename,
+			WorkDir:  pkg.cfg.WorkDir,
+			Repo:     pkg.cfg.Repo,
+		}
+
+		if f.Doc != nil {
+			return loc
+		}
+
+		if i == 0 {
+			first = loc
+		}
+	}
+
+	return first
+}
@@ -0,0 +1,54 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/cloudogu/gomarkdoc/lang"
+)
+
+func TestLintPackage(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/lang/lint")
+	is.NoErr(err)
+
+	res := lang.LintPackage(pkg)
+	is.Equal(res.ImportPath, pkg.ImportPath())
+	is.Equal(res.Symbols, 9)
+	is.Equal(res.Documented, 6)
+
+	type problem struct {
+		rule   lang.LintRule
+		symbol string
+		msg    string
+		line   int
+	}
+
+	var problems []problem
+	for _, p := range res.Problems {
+		problems = append(problems, problem{p.Rule, p.Symbol, p.Message, p.Location.Start.Line})
+	}
+
+	is.Equal(problems, []problem{
+		{
+			lang.BrokenDocLinkRule,
+			pkg.ImportPath(),
+			"documentation of " + pkg.ImportPath() + " has a broken doc link [Missing]",
+			3,
+		},
+		{lang.MissingDocRule, "Run", "Run has no documentation", 28},
+		{lang.MissingFieldDocRule, "Config.Value", "field Config.Value has no documentation", 12},
+		{lang.BrokenDocLinkRule, "Server.Start", "documentation of Server.Start has a broken doc link [Config.Unknown]", 21},
+		{lang.DocPrefixRule, "Server.Stop", `documentation of Server.Stop should start with "Stop"`, 24},
+		{lang.MissingDocRule, "Undocumented", "Undocumented has no documentation", 26},
+	})
+}
+
+func TestLintResult_Coverage(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal((&lang.LintResult{}).Coverage(), 100.0)
+	is.Equal((&lang.LintResult{Symbols: 4, Documented: 3}).Coverage(), 75.0)
+}
@@ -0,0 +1,28 @@
+package lang
+
+type (
+	// ModIndex holds information for rendering an index of packages which are
+	// documented in separate files, such as the packages of a module.
+	ModIndex struct {
+		Header   string
+		Footer   string
+		Packages []*ModIndexEntry
+	}
+
+	// ModIndexEntry is a package listed by a ModIndex along with the
+	// reference to the file documenting it, relative to the index.
+	ModIndexEntry struct {
+		*Package
+		Href string
+	}
+)
+
+// NewModIndex creates a new instance of ModIndex with the provided
+// information.
+func NewModIndex(header, footer string, packages []*ModIndexEntry) *ModIndex {
+	return &ModIndex{
+		Header:   header,
+		Footer:   footer,
+		Packages: packages,
+	}
+}
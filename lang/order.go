@@ -0,0 +1,359 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// Order defines the order in which the types, functions and struct fields of a
+// package are listed.
+type Order string
+
+const (
+	// DefaultOrder keeps the order provided by the doc package, which sorts
+	// types and functions alphabetically, while struct fields are listed in
+	// declaration order.
+	DefaultOrder Order = ""
+
+	// AlphabeticalOrder sorts by name.
+	AlphabeticalOrder Order = "alphabetical"
+
+	// DeclarationOrder lists in the order of declaration within the source
+	// files of the package, with the files sorted by name.
+	DeclarationOrder Order = "declaration"
+
+	// FileOrder lists the symbols declared in the same source file together,
+	// with the files sorted by name and the symbols of a file sorted
+	// alphabetically.
+	FileOrder Order = "file"
+
+	// GroupOrder lists the symbols of the same //gomarkdoc:group together.
+	// Symbols without a group come first, followed by the groups configured
+	// using ConfigWithGroupOrder and the remaining groups in the order of their
+	// first declaration. Within a group, symbols are listed in declaration
+	// order.
+	GroupOrder Order = "group"
+
+	// TreeOrder lists each type before the types it references, starting with
+	// the types which are not referenced by any other type of the package. The
+	// referenced types follow in the order in which they are referenced, e.g.
+	// by the fields of a struct. This follows the structure of nested
+	// configuration types, starting at the root configuration struct. Functions
+	// and struct fields are listed in declaration order.
+	TreeOrder Order = "tree"
+)
+
+var orders = []Order{AlphabeticalOrder, DeclarationOrder, FileOrder, GroupOrder, TreeOrder}
+
+// Orders provides the names of the available orders in alphabetical order.
+func Orders() []string {
+	names := make([]string, len(orders))
+	for i, o := range orders {
+		names[i] = string(o)
+	}
+
+	return names
+}
+
+// ParseOrder provides the order with the provided name. An empty name selects
+// the DefaultOrder.
+func ParseOrder(name string) (Order, error) {
+	if name == "" {
+		return DefaultOrder, nil
+	}
+
+	for _, o := range orders {
+		if string(o) == name {
+			return o, nil
+		}
+	}
+
+	return DefaultOrder, fmt.Errorf(
+		"gomarkdoc: invalid order %q. Valid options: %s",
+		name,
+		strings.Join(Orders(), ", "),
+	)
+}
+
+// OrderTypes provides a copy of the list of types in the provided order.
+func OrderTypes(order Order, types []*Type) []*Type {
+	if len(types) == 0 {
+		return types
+	}
+
+	entries := make([]orderEntry, len(types))
+	for i, typ := range types {
+		entries[i] = orderEntry{name: typ.Name(), group: typ.Group()}
+		if typ.doc.Decl != nil {
+			entries[i].pos = typ.doc.Decl.Pos()
+			entries[i].refs = typeRefs(typ.doc.Decl)
+		}
+	}
+
+	ordered := make([]*Type, len(types))
+	for i, idx := range types[0].cfg.orderIndices(order, entries) {
+		ordered[i] = types[idx]
+	}
+
+	return ordered
+}
+
+// OrderFuncs provides a copy of the list of funcs in the provided order. The
+// TreeOrder lists funcs in declaration order.
+func OrderFuncs(order Order, funcs []*Func) []*Func {
+	if len(funcs) == 0 {
+		return funcs
+	}
+
+	entries := make([]orderEntry, len(funcs))
+	for i, fn := range funcs {
+		entries[i] = orderEntry{name: fn.Name(), group: fn.Group()}
+		if fn.doc.Decl != nil {
+			entries[i].pos = fn.doc.Decl.Pos()
+		}
+	}
+
+	ordered := make([]*Func, len(funcs))
+	for i, idx := range funcs[0].cfg.orderIndices(order, entries) {
+		ordered[i] = funcs[idx]
+	}
+
+	return ordered
+}
+
+// OrderFields provides a copy of the list of struct fields in the provided
+// order. The FileOrder and TreeOrder list fields in declaration order.
+func OrderFields(order Order, fields []*Field) []*Field {
+	if len(fields) == 0 {
+		return fields
+	}
+
+	entries := make([]orderEntry, len(fields))
+	for i, f := range fields {
+		entries[i] = orderEntry{name: fieldName(f.doc), pos: f.doc.Pos(), group: f.Group()}
+	}
+
+	if order == FileOrder {
+		order = DeclarationOrder
+	}
+
+	ordered := make([]*Field, len(fields))
+	for i, idx := range fields[0].cfg.orderIndices(order, entries) {
+		ordered[i] = fields[idx]
+	}
+
+	return ordered
+}
+
+// orderEntry describes a type, func or struct field for the purpose of
+// ordering.
+type orderEntry struct {
+	name  string
+	pos   token.Pos
+	group string
+
+	// refs holds the names of the types referenced by a type, which are used
+	// for the TreeOrder.
+	refs []string
+}
+
+// orderIndices provides the indices of the entries in the provided order.
+func (c *Config) orderIndices(order Order, entries []orderEntry) []int {
+	indices := make([]int, len(entries))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	switch order {
+	case AlphabeticalOrder:
+		sort.SliceStable(indices, func(i, j int) bool {
+			return entries[indices[i]].name < entries[indices[j]].name
+		})
+	case DeclarationOrder:
+		sortByPos(indices, entries)
+	case FileOrder:
+		sort.SliceStable(indices, func(i, j int) bool {
+			a, b := entries[indices[i]], entries[indices[j]]
+			if fa, fb := c.filename(a.pos), c.filename(b.pos); fa != fb {
+				return fa < fb
+			}
+
+			return a.name < b.name
+		})
+	case GroupOrder:
+		sortByPos(indices, entries)
+
+		groups := make([]string, len(entries))
+		for i, e := range entries {
+			groups[i] = e.group
+		}
+
+		ranks := c.groupRanks(groups, indices)
+		sort.SliceStable(indices, func(i, j int) bool {
+			return ranks[entries[indices[i]].group] < ranks[entries[indices[j]].group]
+		})
+	case TreeOrder:
+		sortByPos(indices, entries)
+		indices = treeOrder(indices, entries)
+	}
+
+	return indices
+}
+
+// groupRanks ranks the names of the provided groups. The empty group comes
+// first, followed by the groups configured using ConfigWithGroupOrder and the
+// remaining groups in the order of their first appearance when visiting the
+// groups in the order of the provided indices.
+func (c *Config) groupRanks(groups []string, indices []int) map[string]int {
+	ranks := map[string]int{"": 0}
+	for _, name := range c.groupOrder {
+		if _, ok := ranks[name]; !ok {
+			ranks[name] = len(ranks)
+		}
+	}
+
+	for _, idx := range indices {
+		if _, ok := ranks[groups[idx]]; !ok {
+			ranks[groups[idx]] = len(ranks)
+		}
+	}
+
+	return ranks
+}
+
+// filename provides the name of the file containing the position.
+func (c *Config) filename(pos token.Pos) string {
+	if c.FileSet == nil || !pos.IsValid() {
+		return ""
+	}
+
+	return c.FileSet.Position(pos).Filename
+}
+
+func sortByPos(indices []int, entries []orderEntry) {
+	sort.SliceStable(indices, func(i, j int) bool {
+		return entries[indices[i]].pos < entries[indices[j]].pos
+	})
+}
+
+// treeOrder lists each entry before the entries it references, starting with
+// the entries which are not referenced by any other entry. The provided
+// indices define the order of the entries without references between them.
+// Entries which are only referenced within a cycle are visited once all other
+// entries were listed.
+func treeOrder(indices []int, entries []orderEntry) []int {
+	byName := make(map[string]int, len(entries))
+	for i, e := range entries {
+		byName[e.name] = i
+	}
+
+	referenced := make(map[int]bool)
+	for i, e := range entries {
+		for _, ref := range e.refs {
+			if j, ok := byName[ref]; ok && j != i {
+				referenced[j] = true
+			}
+		}
+	}
+
+	var (
+		ordered []int
+		visited = make(map[int]bool)
+		visit   func(i int)
+	)
+
+	visit = func(i int) {
+		if visited[i] {
+			return
+		}
+
+		visited[i] = true
+		ordered = append(ordered, i)
+		for _, ref := range entries[i].refs {
+			if j, ok := byName[ref]; ok {
+				visit(j)
+			}
+		}
+	}
+
+	for _, i := range indices {
+		if !referenced[i] {
+			visit(i)
+		}
+	}
+
+	for _, i := range indices {
+		visit(i)
+	}
+
+	return ordered
+}
+
+// typeRefs provides the names of the types of the package referenced by the
+// declared type in the order of their first appearance, e.g. as the types of
+// struct fields.
+func typeRefs(decl *ast.GenDecl) []string {
+	var (
+		refs []string
+		seen = make(map[string]bool)
+	)
+
+	var inspect func(node ast.Node) bool
+	inspect = func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.Field:
+			// Only the types of fields and methods are relevant, not their
+			// names.
+			ast.Inspect(n.Type, inspect)
+			return false
+		case *ast.SelectorExpr:
+			// Types of other packages are never part of the tree.
+			return false
+		case *ast.Ident:
+			if !seen[n.Name] {
+				seen[n.Name] = true
+				refs = append(refs, n.Name)
+			}
+		}
+
+		return true
+	}
+
+	for _, spec := range decl.Specs {
+		if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+			ast.Inspect(typeSpec.Type, inspect)
+		}
+	}
+
+	return refs
+}
+
+// fieldName provides the name of a struct field. Embedded fields are named
+// after their type without pointer, package qualifier or type arguments, as
+// defined by the Go spec.
+func fieldName(field *ast.Field) string {
+	if len(field.Names) > 0 {
+		return field.Names[0].Name
+	}
+
+	expr := field.Type
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.SelectorExpr:
+			return e.Sel.Name
+		case *ast.Ident:
+			return e.Name
+		default:
+			return ""
+		}
+	}
+}
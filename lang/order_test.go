@@ -0,0 +1,36 @@
+package lang
+
+import (
+	"go/ast"
+	"go/parser"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestFieldName(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		want  string
+	}{
+		{"named", "a, b int", "a"},
+		{"embedded", "Config", "Config"},
+		{"pointer", "*Config", "Config"},
+		{"qualified", "*strings.Builder", "Builder"},
+		{"type argument", "List[int]", "List"},
+		{"type arguments", "*maps.Map[string, int]", "Map"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+
+			expr, err := parser.ParseExpr("struct{ " + test.field + " }")
+			is.NoErr(err)
+
+			field := expr.(*ast.StructType).Fields.List[0]
+			is.Equal(fieldName(field), test.want)
+		})
+	}
+}
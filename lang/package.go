@@ -17,11 +17,6 @@ import (
 	"github.com/cloudogu/gomarkdoc/logger"
 )
 
-var (
-	currentPackage string
-	knownTypes     []*doc.Type
-)
-
 type (
 	// Package holds documentation information for a package and all of the
 	// symbols contained within it.
@@ -37,6 +32,11 @@ type (
 		includeUnexported   bool
 		repositoryOverrides *Repo
 		includeFiles        []string
+		excludeDeprecated   bool
+		catalog             *Catalog
+		order               Order
+		groupOrder          []string
+		remoteNames         []string
 	}
 
 	// PackageOption configures one or more options for the package.
@@ -48,7 +48,15 @@ type (
 // recommended for advanced scenarios. Most consumers will find it easier to use
 // NewPackageFromBuild instead.
 func NewPackage(cfg *Config, doc *doc.Package, examples []*doc.Example) *Package {
-	return &Package{cfg, doc, examples}
+	// The package information is carried along with a copy of the config so
+	// that every Doc created for the package can recognize [comment.DocLink]
+	// references, while the config of the caller can be reused for other
+	// packages.
+	pkgCfg := *cfg
+	pkgCfg.pkgName = doc.Name
+	pkgCfg.pkgTypes = doc.Types
+
+	return &Package{&pkgCfg, doc, examples}
 }
 
 // NewPackageFromBuild creates a representation of a package's documentation
@@ -67,16 +75,39 @@ func NewPackageFromBuild(log logger.Logger, pkg *build.Package, opts ...PackageO
 		return nil, err
 	}
 
-	cfg, err := NewConfig(log, wd, pkg.Dir, ConfigWithRepoOverrides(options.repositoryOverrides))
+	cfgOpts := []ConfigOption{ConfigWithRepoOverrides(options.repositoryOverrides)}
+	if options.excludeDeprecated {
+		cfgOpts = append(cfgOpts, ConfigWithDeprecatedExcluded())
+	}
+
+	if options.catalog != nil {
+		cfgOpts = append(cfgOpts, ConfigWithCatalog(options.catalog))
+	}
+
+	if options.order != DefaultOrder {
+		cfgOpts = append(cfgOpts, ConfigWithOrder(options.order))
+	}
+
+	if len(options.groupOrder) > 0 {
+		cfgOpts = append(cfgOpts, ConfigWithGroupOrder(options.groupOrder))
+	}
+
+	if len(options.remoteNames) > 0 {
+		cfgOpts = append(cfgOpts, ConfigWithRemoteNames(options.remoteNames))
+	}
+
+	cfg, err := NewConfig(log, wd, pkg.Dir, cfgOpts...)
 	if err != nil {
 		return nil, err
 	}
 
-	docPkg, err := getDocPkg(pkg, cfg.FileSet, options.includeUnexported, options.includeFiles)
+	docPkg, directives, err := getDocPkg(pkg, cfg.FileSet, options.includeUnexported, options.includeFiles)
 	if err != nil {
 		return nil, err
 	}
 
+	cfg.directives = directives
+
 	files, err := parsePkgFiles(pkg, cfg.FileSet)
 	if err != nil {
 		return nil, err
@@ -84,12 +115,6 @@ func NewPackageFromBuild(log logger.Logger, pkg *build.Package, opts ...PackageO
 
 	examples := doc.Examples(files...)
 
-	// Setting this package variables is necessary for the [comment.DocLink] generation.
-	// With this information every .Doc can use this to modify the parser and recognize [comment.DocLink]
-	// in paragraphs.
-	currentPackage = docPkg.Name
-	knownTypes = docPkg.Types
-
 	return NewPackage(cfg, docPkg, examples), nil
 }
 
@@ -122,6 +147,60 @@ func PackageWithIncludeFiles(includeFiles []string) PackageOption {
 	}
 }
 
+// PackageWithCatalog can be used along with the NewPackageFromBuild function to
+// translate the titles of the package and its symbols using the provided
+// catalog.
+func PackageWithCatalog(catalog *Catalog) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.catalog = catalog
+		return nil
+	}
+}
+
+// PackageWithOrder can be used along with the NewPackageFromBuild function to
+// list the types, funcs and struct fields of the package in the provided
+// order.
+func PackageWithOrder(order Order) PackageOption {
+	return func(opts *PackageOptions) error {
+		if _, err := ParseOrder(string(order)); err != nil {
+			return err
+		}
+
+		opts.order = order
+		return nil
+	}
+}
+
+// PackageWithGroupOrder can be used along with the NewPackageFromBuild
+// function to define the order of the groups set using the //gomarkdoc:group
+// directive.
+func PackageWithGroupOrder(groups []string) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.groupOrder = groups
+		return nil
+	}
+}
+
+// PackageWithRemoteNames can be used along with the NewPackageFromBuild
+// function to define the names of the git remotes which the repository
+// information is detected from, in order of preference.
+func PackageWithRemoteNames(names []string) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.remoteNames = names
+		return nil
+	}
+}
+
+// PackageWithDeprecatedExcluded can be used along with the NewPackageFromBuild
+// function to omit deprecated symbols, struct fields and interface methods from
+// the documentation for the package.
+func PackageWithDeprecatedExcluded() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.excludeDeprecated = true
+		return nil
+	}
+}
+
 // Level provides the default level that headers for the package's root
 // documentation should be rendered.
 func (pkg *Package) Level() int {
@@ -147,11 +226,10 @@ func (pkg *Package) Name() string {
 
 // Title provides the formatted name of the package.
 func (pkg *Package) Title() string {
-	format := "package %s"
 	if pkg.Name() == "main" {
-		return fmt.Sprintf(format, pkg.Dirname())
+		return pkg.cfg.catalog.Translate("package %s", pkg.Dirname())
 	}
-	return fmt.Sprintf(format, pkg.Name())
+	return pkg.cfg.catalog.Translate("package %s", pkg.Name())
 }
 
 // Import provides the raw text for the import declaration that is used to
@@ -172,19 +250,36 @@ func (pkg *Package) ImportPath() string {
 // Summary provides the one-sentence summary of the package's documentation
 // comment.
 func (pkg *Package) Summary() string {
-	return extractSummary(pkg.doc.Doc)
+	return extractSummary(withoutDeprecation(pkg.doc.Doc))
 }
 
 // Doc provides the structured contents of the documentation comment for the
 // package.
 func (pkg *Package) Doc() *Doc {
 	// TODO: level should only be + 1, but we have special knowledge for rendering
-	return NewDoc(pkg.cfg.Inc(2), pkg.doc.Doc)
+	return NewDoc(pkg.cfg.Inc(2), withoutDeprecation(pkg.doc.Doc))
+}
+
+// Deprecated reports whether the package is deprecated, i.e. its documentation
+// comment contains a paragraph starting with "Deprecated:".
+func (pkg *Package) Deprecated() bool {
+	return isDeprecated(pkg.doc.Doc)
+}
+
+// DeprecationNote provides the text of the deprecation paragraph of the
+// package's documentation comment without the "Deprecated:" prefix. The
+// paragraph is not part of Doc, so that it can be rendered separately.
+func (pkg *Package) DeprecationNote() string {
+	return deprecationNote(pkg.doc.Doc)
 }
 
 // Consts lists the top-level constants provided by the package.
 func (pkg *Package) Consts() (consts []*Value) {
 	for _, c := range pkg.doc.Consts {
+		if pkg.cfg.omitValue(c) {
+			continue
+		}
+
 		consts = append(consts, NewValue(pkg.cfg.Inc(1), c))
 	}
 
@@ -194,28 +289,42 @@ func (pkg *Package) Consts() (consts []*Value) {
 // Vars lists the top-level variables provided by the package.
 func (pkg *Package) Vars() (vars []*Value) {
 	for _, v := range pkg.doc.Vars {
+		if pkg.cfg.omitValue(v) {
+			continue
+		}
+
 		vars = append(vars, NewValue(pkg.cfg.Inc(1), v))
 	}
 
 	return
 }
 
-// Funcs lists the top-level functions provided by the package.
+// Funcs lists the top-level functions provided by the package in the
+// configured order.
 func (pkg *Package) Funcs() (funcs []*Func) {
 	for _, fn := range pkg.doc.Funcs {
+		if pkg.cfg.omitFunc(fn) {
+			continue
+		}
+
 		funcs = append(funcs, NewFunc(pkg.cfg.Inc(1), fn, pkg.examples))
 	}
 
-	return
+	return OrderFuncs(pkg.cfg.order, funcs)
 }
 
-// Types lists the top-level types provided by the package.
+// Types lists the top-level types provided by the package in the configured
+// order.
 func (pkg *Package) Types() (types []*Type) {
 	for _, typ := range pkg.doc.Types {
+		if pkg.cfg.omitType(typ) {
+			continue
+		}
+
 		types = append(types, NewType(pkg.cfg.Inc(1), typ, pkg.examples))
 	}
 
-	return
+	return OrderTypes(pkg.cfg.order, types)
 }
 
 // Examples provides the package-level examples that have been defined. This
@@ -307,7 +416,7 @@ func findFileInParent(dir, filename string, fileIsDir bool) (*os.File, bool) {
 	return nil, false
 }
 
-func getDocPkg(pkg *build.Package, fs *token.FileSet, includeUnexported bool, includeFiles []string) (*doc.Package, error) {
+func getDocPkg(pkg *build.Package, fs *token.FileSet, includeUnexported bool, includeFiles []string) (*doc.Package, map[ast.Node]directives, error) {
 	pkgs, err := parser.ParseDir(
 		fs,
 		pkg.Dir,
@@ -341,21 +450,25 @@ func getDocPkg(pkg *build.Package, fs *token.FileSet, includeUnexported bool, in
 	)
 
 	if err != nil {
-		return nil, fmt.Errorf("gomarkdoc: failed to parse package: %w", err)
+		return nil, nil, fmt.Errorf("gomarkdoc: failed to parse package: %w", err)
 	}
 
 	if len(pkgs) == 0 {
-		return nil, fmt.Errorf("gomarkdoc: no source-code package in directory %s", pkg.Dir)
+		return nil, nil, fmt.Errorf("gomarkdoc: no source-code package in directory %s", pkg.Dir)
 	}
 
 	if len(pkgs) > 1 {
-		return nil, fmt.Errorf("gomarkdoc: multiple packages in directory %s", pkg.Dir)
+		return nil, nil, fmt.Errorf("gomarkdoc: multiple packages in directory %s", pkg.Dir)
 	}
 
 	astPkg := pkgs[pkg.Name]
 
-	if !includeUnexported {
-		ast.PackageExports(astPkg)
+	// Unexported symbols are filtered by the doc package rather than with
+	// ast.PackageExports, which would also drop the type set elements of
+	// constraint interfaces.
+	mode := doc.Mode(0)
+	if includeUnexported {
+		mode = doc.AllDecls
 	}
 
 	importPath := pkg.ImportPath
@@ -369,7 +482,11 @@ func getDocPkg(pkg *build.Package, fs *token.FileSet, includeUnexported bool, in
 		}
 	}
 
-	return doc.New(astPkg, importPath, doc.AllDecls), nil
+	// The directives have to be collected before the doc package removes the
+	// doc comments of the declarations.
+	directives := collectDirectives(astPkg.Files)
+
+	return doc.New(astPkg, importPath, mode), directives, nil
 }
 
 func parsePkgFiles(pkg *build.Package, fs *token.FileSet) ([]*ast.File, error) {
@@ -1,7 +1,9 @@
 package lang_test
 
 import (
+	"fmt"
 	"go/build"
+	"go/doc"
 	"os"
 	"path/filepath"
 	"strings"
@@ -156,6 +158,209 @@ func TestPackage_encoding(t *testing.T) {
 	is.Equal(len(pkg.Examples()), 0) // encoding should have no top-level examples
 }
 
+func TestPackage_deprecated(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/lang/deprecated")
+	is.NoErr(err)
+
+	is.True(pkg.Deprecated())
+	is.Equal(pkg.DeprecationNote(), "Use package function instead.")
+	is.Equal(pkg.Summary(), "Package deprecated contains symbols marked as deprecated.")
+	is.Equal(len(pkg.Doc().Blocks()), 1) // deprecation paragraph is not part of the doc
+
+	is.Equal(len(pkg.Consts()), 2)
+	is.True(!pkg.Consts()[0].Deprecated())
+	is.True(pkg.Consts()[1].Deprecated())
+	is.Equal(pkg.Consts()[1].DeprecationNote(), "Use Current instead.")
+
+	is.Equal(len(pkg.Funcs()), 2)
+	is.True(!pkg.Funcs()[0].Deprecated())
+	is.True(pkg.Funcs()[1].Deprecated())
+
+	types := pkg.Types()
+	is.Equal(len(types), 3)
+	is.Equal(types[0].Name(), "Options")
+	is.True(types[0].Deprecated())
+
+	fields := types[1].Fields()
+	is.Equal(len(fields), 2)
+	is.True(fields[1].Deprecated())
+	is.Equal(fields[1].DeprecationNote(), "Use Name instead. Alias will be removed in the next major version.")
+	is.Equal(fields[1].Summary(), "Alias is an alternative name.")
+
+	methods := types[2].InterfaceMethods()
+	is.Equal(len(methods), 2)
+	is.True(methods[1].Deprecated())
+	is.Equal(len(methods[1].Doc().Blocks()), 0)
+}
+
+func TestPackage_deprecatedExcluded(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/deprecated")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithDeprecatedExcluded())
+	is.NoErr(err)
+
+	is.Equal(len(pkg.Consts()), 1)
+	is.Equal(len(pkg.Funcs()), 1)
+	is.Equal(pkg.Funcs()[0].Name(), "Run")
+
+	types := pkg.Types()
+	is.Equal(len(types), 2)
+	is.Equal(types[0].Name(), "Settings")
+	is.Equal(len(types[0].Fields()), 1)
+
+	decl, err := types[0].Decl()
+	is.NoErr(err)
+	is.Equal(decl, "type Settings struct {\n    Name string\n    // contains filtered or unexported fields\n}")
+
+	is.Equal(types[1].Name(), "Store")
+	is.Equal(len(types[1].InterfaceMethods()), 1)
+}
+
+func TestPackage_directives(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/lang/directives")
+	is.NoErr(err)
+
+	is.Equal(len(pkg.Consts()), 1)
+
+	funcs := pkg.Funcs()
+	is.Equal(len(funcs), 1)
+	is.Equal(funcs[0].CustomTitle(), "Run it")
+	is.Equal(funcs[0].Title(), "Run it")
+	is.Equal(funcs[0].Doc().Blocks()[0].Text(), "Run runs.")
+
+	types := pkg.Types()
+	is.Equal(len(types), 1)
+
+	server := types[0]
+	is.Equal(server.Title(), "The Server")
+	is.Equal(server.Group(), "core")
+	is.Equal(len(server.Doc().Blocks()), 1)
+	is.Equal(server.Summary(), "Server serves requests.")
+
+	methods := server.Methods()
+	is.Equal(len(methods), 1)
+	is.Equal(methods[0].Name(), "Serve")
+	is.Equal(methods[0].Group(), "core")
+	is.Equal(methods[0].Title(), "func (*Server) Serve")
+
+	fields := server.Fields()
+	is.Equal(len(fields), 4)
+	is.Equal(fields[2].Title(), "Request timeout")
+	is.Equal(len(fields[2].Doc().Blocks()), 1)
+
+	groups := server.FieldGroups()
+	is.Equal(len(groups), 3)
+	is.Equal(groups[0].Name(), "")
+	is.Equal(len(groups[0].Fields()), 1)
+	is.Equal(groups[0].Fields()[0].Level(), 3)
+	is.Equal(groups[1].Title(), "Network")
+	is.Equal(groups[1].Level(), 3)
+	is.Equal(len(groups[1].Fields()), 2)
+	is.Equal(groups[1].Fields()[1].Name(), "Timeout")
+	is.Equal(groups[1].Fields()[1].Level(), 4)
+	is.Equal(groups[2].Title(), "Tuning")
+
+	// Doc links point at the custom title of the type.
+	is.Equal(methods[0].Doc().Blocks()[0].Spans()[1].URL(), "#the-server")
+	is.Equal(groups[2].Fields()[0].Doc().Blocks()[0].Spans()[1].URL(), "#the-server")
+
+	decl, err := server.Decl()
+	is.NoErr(err)
+	is.True(!strings.Contains(decl, "Debug"))
+	is.True(strings.Contains(decl, "// contains filtered or unexported fields"))
+}
+
+func TestPackage_order(t *testing.T) {
+	tests := []struct {
+		order      lang.Order
+		groupOrder []string
+		types      string
+		funcs      string
+		fields     string
+	}{
+		{lang.DefaultOrder, nil, "Alpha Config LogConfig ServerConfig TLSConfig", "Load Start", "Server Log Zone Age"},
+		{lang.AlphabeticalOrder, nil, "Alpha Config LogConfig ServerConfig TLSConfig", "Load Start", "Age Log Server Zone"},
+		{lang.DeclarationOrder, nil, "ServerConfig TLSConfig Config LogConfig Alpha", "Start Load", "Server Log Zone Age"},
+		{lang.FileOrder, nil, "ServerConfig TLSConfig Alpha Config LogConfig", "Start Load", "Server Log Zone Age"},
+		{lang.GroupOrder, nil, "ServerConfig LogConfig Alpha TLSConfig Config", "Start Load", "Server Log Zone Age"},
+		{lang.GroupOrder, []string{"core"}, "ServerConfig LogConfig Alpha Config TLSConfig", "Start Load", "Server Log Zone Age"},
+		{lang.TreeOrder, nil, "Config ServerConfig TLSConfig LogConfig Alpha", "Start Load", "Server Log Zone Age"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s %v", test.order, test.groupOrder), func(t *testing.T) {
+			is := is.New(t)
+
+			buildPkg, err := getBuildPackage("../testData/lang/order")
+			is.NoErr(err)
+
+			log := logger.New(logger.ErrorLevel)
+			pkg, err := lang.NewPackageFromBuild(
+				log,
+				buildPkg,
+				lang.PackageWithOrder(test.order),
+				lang.PackageWithGroupOrder(test.groupOrder),
+			)
+			is.NoErr(err)
+
+			var types, funcs []string
+			var config *lang.Type
+			for _, typ := range pkg.Types() {
+				types = append(types, typ.Name())
+				if typ.Name() == "Config" {
+					config = typ
+				}
+			}
+
+			for _, fn := range pkg.Funcs() {
+				funcs = append(funcs, fn.Name())
+			}
+
+			var fields []string
+			for _, f := range config.Fields() {
+				fields = append(fields, f.Name())
+			}
+
+			is.Equal(strings.Join(types, " "), test.types)
+			is.Equal(strings.Join(funcs, " "), test.funcs)
+			is.Equal(strings.Join(fields, " "), test.fields)
+		})
+	}
+}
+
+func TestPackage_orderInvalid(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/order")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	_, err = lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithOrder("random"))
+	is.True(err != nil)
+	is.Equal(err.Error(), `gomarkdoc: invalid order "random". Valid options: alphabetical, declaration, file, group, tree`)
+}
+
+func TestNewPackage_sharedConfig(t *testing.T) {
+	is := is.New(t)
+
+	cfg := &lang.Config{Level: 1}
+	a := lang.NewPackage(cfg, &doc.Package{Name: "a", Doc: "Uses [a.Client].", Types: []*doc.Type{{Name: "Client"}}}, nil)
+	_ = lang.NewPackage(cfg, &doc.Package{Name: "b", Doc: "Uses [b.Server].", Types: []*doc.Type{{Name: "Server"}}}, nil)
+
+	spans := a.Doc().Blocks()[0].Spans()
+	is.Equal(len(spans), 3)
+	is.Equal(spans[1].Kind(), lang.DocLinkSpan)
+	is.Equal(spans[1].URL(), "#type-client") // resolved within package a
+}
+
 func getBuildPackage(path string) (*build.Package, error) {
 	wd, err := os.Getwd()
 	if err != nil {
@@ -0,0 +1,260 @@
+package lang
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cloudogu/gomarkdoc/logger"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// RefKind defines the kind of git ref which source links point at.
+type RefKind string
+
+const (
+	// BranchRef points source links at a branch, which is the default branch
+	// of the repository unless a Ref is set.
+	BranchRef RefKind = "branch"
+
+	// TagRef points source links at a tag. Without a Ref, the nearest tag
+	// reachable from the HEAD commit is used.
+	TagRef RefKind = "tag"
+
+	// CommitRef points source links at a commit. Without a Ref, the HEAD
+	// commit is used.
+	CommitRef RefKind = "commit"
+)
+
+var refKinds = []RefKind{BranchRef, CommitRef, TagRef}
+
+var commitHashRegex = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// ParseRefKind provides the kind of ref with the provided name. An empty name
+// is valid and leaves the kind to be detected.
+func ParseRefKind(name string) (RefKind, error) {
+	if name == "" {
+		return "", nil
+	}
+
+	for _, k := range refKinds {
+		if string(k) == name {
+			return k, nil
+		}
+	}
+
+	return "", fmt.Errorf("gomarkdoc: invalid ref kind %q. Valid options: branch, commit, tag", name)
+}
+
+// LinkRef provides the ref which source links point at along with its kind.
+// This is the Ref if one is set and the DefaultBranch otherwise.
+func (r *Repo) LinkRef() (string, RefKind) {
+	if r.Ref == "" {
+		return r.DefaultBranch, BranchRef
+	}
+
+	if r.RefKind == "" {
+		return r.Ref, BranchRef
+	}
+
+	return r.Ref, r.RefKind
+}
+
+// ResolveRef resolves the ref of the provided kind for the git repository
+// containing the directory. For a CommitRef, this is the hash of the HEAD
+// commit. For a TagRef, this is the nearest tag reachable from the HEAD
+// commit, falling back to the hash of the HEAD commit if there is no such tag.
+// The kind of the resolved ref is returned along with it.
+func ResolveRef(dir string, kind RefKind) (string, RefKind, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return resolveRef(repo, kind)
+}
+
+func resolveRef(repo *git.Repository, kind RefKind) (string, RefKind, error) {
+	if kind != CommitRef && kind != TagRef {
+		return "", "", fmt.Errorf("gomarkdoc: unable to resolve ref of kind %q", kind)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", "", fmt.Errorf("unable to resolve HEAD: %w", err)
+	}
+
+	if kind == TagRef {
+		tag, err := nearestTag(repo, head.Hash())
+		if err != nil {
+			return "", "", err
+		}
+
+		if tag != "" {
+			return tag, TagRef, nil
+		}
+	}
+
+	return head.Hash().String(), CommitRef, nil
+}
+
+// nearestTag provides the tag pointing at the commit closest to the provided
+// commit among its ancestors, including the commit itself. If several tags
+// point at the same commit, the greatest version is used as compared by
+// tagLess. An empty name is
+// returned if none of the ancestors is tagged.
+func nearestTag(repo *git.Repository, from plumbing.Hash) (string, error) {
+	tags, err := repo.Tags()
+	if err != nil {
+		return "", err
+	}
+
+	tagged := make(map[plumbing.Hash][]string)
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		target := ref.Hash()
+
+		// Annotated tags point at a tag object instead of the commit.
+		if obj, err := repo.TagObject(ref.Hash()); err == nil {
+			commit, err := obj.Commit()
+			if err != nil {
+				return nil
+			}
+
+			target = commit.Hash
+		}
+
+		tagged[target] = append(tagged[target], ref.Name().Short())
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(tagged) == 0 {
+		return "", nil
+	}
+
+	// Visit the ancestors breadth first, so that the tag with the fewest
+	// commits in between is found first.
+	queue := []plumbing.Hash{from}
+	visited := map[plumbing.Hash]bool{from: true}
+	for len(queue) > 0 {
+		hash := queue[0]
+		queue = queue[1:]
+
+		if names, ok := tagged[hash]; ok {
+			sort.Slice(names, func(i, j int) bool { return tagLess(names[i], names[j]) })
+			return names[len(names)-1], nil
+		}
+
+		commit, err := object.GetCommit(repo.Storer, hash)
+		if err != nil {
+			return "", err
+		}
+
+		for _, parent := range commit.ParentHashes {
+			if !visited[parent] {
+				visited[parent] = true
+				queue = append(queue, parent)
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// tagLess orders tag names like versions. Numbers within the names are
+// compared by their value, so that v1.10.0 follows v1.9.0, and a release
+// follows its pre-releases, so that v1.1.0 follows v1.1.0-rc1.
+func tagLess(a, b string) bool {
+	switch {
+	case strings.HasPrefix(a, b+"-"):
+		return true
+	case strings.HasPrefix(b, a+"-"):
+		return false
+	}
+
+	for a != "" && b != "" {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			na, nb := leadingDigits(a), leadingDigits(b)
+			if ia, ib := strings.TrimLeft(na, "0"), strings.TrimLeft(nb, "0"); ia != ib {
+				if len(ia) != len(ib) {
+					return len(ia) < len(ib)
+				}
+
+				return ia < ib
+			}
+
+			a, b = a[len(na):], b[len(nb):]
+			continue
+		}
+
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+
+		a, b = a[1:], b[1:]
+	}
+
+	return len(a) < len(b)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+
+	return s[:i]
+}
+
+// resolveRepoRef completes the ref of the repository information. A Ref
+// without a RefKind is classified as a commit if it is a commit hash, as a
+// tag if the repository has a tag of that name and as a branch otherwise. A
+// RefKind without a Ref is resolved from the repository as described for
+// ResolveRef.
+func resolveRepoRef(log logger.Logger, dir string, ri *Repo) {
+	switch {
+	case ri.Ref != "" && ri.RefKind == "":
+		ri.RefKind = BranchRef
+		if commitHashRegex.MatchString(ri.Ref) {
+			ri.RefKind = CommitRef
+			return
+		}
+
+		repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{
+			DetectDotGit: true,
+		})
+		if err != nil {
+			return
+		}
+
+		if _, err := repo.Tag(ri.Ref); err == nil {
+			ri.RefKind = TagRef
+		}
+	case ri.Ref == "" && (ri.RefKind == CommitRef || ri.RefKind == TagRef):
+		ref, kind, err := ResolveRef(dir, ri.RefKind)
+		if err != nil {
+			log.Infof("unable to resolve %s for source links, using the default branch instead: %s", ri.RefKind, err)
+			ri.RefKind = ""
+			return
+		}
+
+		if kind != ri.RefKind {
+			log.Infof("no tag found for source links, using commit %s instead", ref)
+		}
+
+		log.Debugf("resolved %s %s for source links", kind, ref)
+		ri.Ref = ref
+		ri.RefKind = kind
+	}
+}
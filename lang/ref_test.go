@@ -0,0 +1,189 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudogu/gomarkdoc/logger"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/matryer/is"
+)
+
+func TestResolveRef(t *testing.T) {
+	is := is.New(t)
+
+	dir, repo := initTestRepo(t)
+	first := commitTestRepo(t, dir, repo, "first")
+
+	// Without tags, the commit is used
+	ref, kind, err := ResolveRef(dir, TagRef)
+	is.NoErr(err)
+	is.Equal(kind, CommitRef)
+	is.Equal(ref, first.String())
+
+	_, err = repo.CreateTag("v1.0.0", first, nil)
+	is.NoErr(err)
+
+	second := commitTestRepo(t, dir, repo, "second")
+	_, err = repo.CreateTag("v1.1.0", second, &git.CreateTagOptions{
+		Tagger:  testSignature(),
+		Message: "release",
+	})
+	is.NoErr(err)
+	_, err = repo.CreateTag("v1.1.0-rc1", second, nil)
+	is.NoErr(err)
+
+	head := commitTestRepo(t, dir, repo, "third")
+
+	ref, kind, err = ResolveRef(dir, TagRef)
+	is.NoErr(err)
+	is.Equal(kind, TagRef)
+	is.Equal(ref, "v1.1.0") // the greatest version of the nearest tagged commit
+
+	ref, kind, err = ResolveRef(dir, CommitRef)
+	is.NoErr(err)
+	is.Equal(kind, CommitRef)
+	is.Equal(ref, head.String())
+
+	_, _, err = ResolveRef(dir, BranchRef)
+	is.True(err != nil)
+}
+
+func TestResolveRepoRef(t *testing.T) {
+	dir, repo := initTestRepo(t)
+	head := commitTestRepo(t, dir, repo, "first")
+
+	_, err := repo.CreateTag("v1.0.0", head, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		repo    Repo
+		ref     string
+		refKind RefKind
+	}{
+		"default branch": {Repo{}, "main", BranchRef},
+		"tag override":   {Repo{Ref: "v1.0.0"}, "v1.0.0", TagRef},
+		"branch override": {
+			Repo{Ref: "release"},
+			"release",
+			BranchRef,
+		},
+		"commit override": {
+			Repo{Ref: "0123456789abcdef0123456789abcdef01234567"},
+			"0123456789abcdef0123456789abcdef01234567",
+			CommitRef,
+		},
+		"explicit kind":  {Repo{Ref: "v1.0.0", RefKind: BranchRef}, "v1.0.0", BranchRef},
+		"pinned commit":  {Repo{RefKind: CommitRef}, head.String(), CommitRef},
+		"pinned tag":     {Repo{RefKind: TagRef}, "v1.0.0", TagRef},
+		"pinned nothing": {Repo{RefKind: BranchRef}, "main", BranchRef},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+
+			ri := test.repo
+			ri.DefaultBranch = "main"
+			resolveRepoRef(logger.New(logger.ErrorLevel), dir, &ri)
+
+			ref, kind := ri.LinkRef()
+			is.Equal(ref, test.ref)
+			is.Equal(kind, test.refKind)
+		})
+	}
+}
+
+func TestResolveRepoRef_noRepository(t *testing.T) {
+	is := is.New(t)
+
+	ri := Repo{DefaultBranch: "main", RefKind: CommitRef}
+	resolveRepoRef(logger.New(logger.ErrorLevel), t.TempDir(), &ri)
+
+	ref, kind := ri.LinkRef()
+	is.Equal(ref, "main")
+	is.Equal(kind, BranchRef)
+}
+
+func TestTagLess(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"v1.0.0", "v1.1.0"},
+		{"v1.9.0", "v1.10.0"},
+		{"v1.1.0-rc1", "v1.1.0"},
+		{"v1.1.0-rc1", "v1.1.0-rc2"},
+		{"v1.1", "v1.1.0"},
+		{"v01.2", "v1.3"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.a+" < "+test.b, func(t *testing.T) {
+			is := is.New(t)
+
+			is.True(tagLess(test.a, test.b))
+			is.True(!tagLess(test.b, test.a))
+		})
+	}
+}
+
+func TestParseRefKind(t *testing.T) {
+	is := is.New(t)
+
+	kind, err := ParseRefKind("tag")
+	is.NoErr(err)
+	is.Equal(kind, TagRef)
+
+	kind, err = ParseRefKind("")
+	is.NoErr(err)
+	is.Equal(kind, RefKind(""))
+
+	_, err = ParseRefKind("head")
+	is.True(err != nil)
+}
+
+func initTestRepo(t *testing.T) (string, *git.Repository) {
+	t.Helper()
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir, repo
+}
+
+func commitTestRepo(t *testing.T, dir string, repo *git.Repository, msg string) plumbing.Hash {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte(msg), 0664); err != nil {
+		t.Fatal(err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := wt.Add("file.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := wt.Commit(msg, &git.CommitOptions{Author: testSignature()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return hash
+}
+
+func testSignature() *object.Signature {
+	return &object.Signature{Name: "test", Email: "test@example.com", When: time.Unix(0, 0)}
+}
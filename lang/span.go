@@ -0,0 +1,153 @@
+package lang
+
+import (
+	"go/doc/comment"
+	"regexp"
+	"strings"
+
+	"github.com/cloudogu/gomarkdoc/format/formatcore"
+)
+
+type (
+	// Span defines a single inline element (e.g. plain text, a link) within a
+	// paragraph in the documentation for a symbol or package.
+	Span struct {
+		kind SpanKind
+		text string
+		url  string
+	}
+
+	// SpanKind identifies the type of inline element represented by the
+	// corresponding Span.
+	SpanKind string
+)
+
+const (
+	// PlainSpan defines a span of plain text.
+	PlainSpan SpanKind = "plain"
+
+	// ItalicSpan defines a span of emphasized text.
+	ItalicSpan SpanKind = "italic"
+
+	// CodeSpan defines a span of inline code, written between backticks in
+	// the documentation comment.
+	CodeSpan SpanKind = "code"
+
+	// LinkSpan defines a link to a URL, either written as [text] with a link
+	// definition or as a plain URL in the documentation comment.
+	LinkSpan SpanKind = "link"
+
+	// DocLinkSpan defines a link to a Go symbol or package, written as
+	// [Name], [Type.Method] or [pkg.Name] in the documentation comment.
+	DocLinkSpan SpanKind = "docLink"
+)
+
+// NewSpan creates a new inline element of the provided kind with the given
+// text and URL. The URL is only relevant for spans of kind LinkSpan and
+// DocLinkSpan.
+func NewSpan(kind SpanKind, text, url string) *Span {
+	return &Span{kind, text, url}
+}
+
+// Kind provides the kind of inline element represented by the span.
+func (s *Span) Kind() SpanKind {
+	return s.kind
+}
+
+// Text provides the raw text of the span. For links, this is the text of the
+// link. The text is not escaped, so that each format can escape it as needed.
+func (s *Span) Text() string {
+	return s.text
+}
+
+// URL provides the destination of a link. Only relevant for spans of kind
+// LinkSpan and DocLinkSpan.
+func (s *Span) URL() string {
+	return s.url
+}
+
+// ParseSpans produces the inline elements of the provided comment text.
+// Whitespace within the text is collapsed.
+func ParseSpans(cfg *Config, text []comment.Text) []*Span {
+	var spans []*Span
+	for _, t := range text {
+		switch v := t.(type) {
+		case comment.Plain:
+			spans = append(spans, splitCodeSpans(collapseWhitespace(string(v)))...)
+		case comment.Italic:
+			spans = append(spans, NewSpan(ItalicSpan, collapseWhitespace(string(v)), ""))
+		case *comment.Link:
+			spans = append(spans, NewSpan(LinkSpan, collapseWhitespace(plainText(v.Text...)), v.URL))
+		case *comment.DocLink:
+			spans = append(spans, NewSpan(DocLinkSpan, collapseWhitespace(plainText(v.Text...)), docLinkURL(cfg, v)))
+		}
+	}
+
+	return spans
+}
+
+// codeSpanRegex matches text written between backticks. Go doc comments have
+// no syntax for inline code, but backticks are commonly used for it.
+var codeSpanRegex = regexp.MustCompile("`([^`]+)`")
+
+// splitCodeSpans separates the text between backticks from the surrounding
+// plain text.
+func splitCodeSpans(text string) []*Span {
+	var (
+		spans  []*Span
+		cursor int
+	)
+
+	for _, loc := range codeSpanRegex.FindAllStringSubmatchIndex(text, -1) {
+		if loc[0] > cursor {
+			spans = append(spans, NewSpan(PlainSpan, text[cursor:loc[0]], ""))
+		}
+
+		spans = append(spans, NewSpan(CodeSpan, text[loc[2]:loc[3]], ""))
+		cursor = loc[1]
+	}
+
+	if cursor < len(text) {
+		spans = append(spans, NewSpan(PlainSpan, text[cursor:], ""))
+	}
+
+	return spans
+}
+
+// spansText provides the markdown text of the spans without escaping, which
+// is used as the text of paragraph and callout blocks.
+func spansText(spans []*Span) string {
+	var b strings.Builder
+	for _, s := range spans {
+		switch s.kind {
+		case CodeSpan:
+			b.WriteString(formatcore.CodeSpan(s.text))
+		case LinkSpan, DocLinkSpan:
+			b.WriteString(formatcore.Link(s.text, s.url))
+		default:
+			b.WriteString(s.text)
+		}
+	}
+
+	return b.String()
+}
+
+// plainText provides the text of the comment text elements without any
+// markup.
+func plainText(text ...comment.Text) string {
+	var b strings.Builder
+	for _, t := range text {
+		switch v := t.(type) {
+		case comment.Plain:
+			b.WriteString(string(v))
+		case comment.Italic:
+			b.WriteString(string(v))
+		case *comment.Link:
+			b.WriteString(plainText(v.Text...))
+		case *comment.DocLink:
+			b.WriteString(plainText(v.Text...))
+		}
+	}
+
+	return b.String()
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/doc"
+	"sort"
 	"strings"
 )
 
@@ -33,9 +34,26 @@ func (typ *Type) Name() string {
 }
 
 // Title provides a formatted name suitable for use in a header identifying the
-// type.
+// type. This is the custom title if one was set using the //gomarkdoc:title
+// directive.
 func (typ *Type) Title() string {
-	return fmt.Sprintf("type %s", typ.doc.Name)
+	if title := typ.CustomTitle(); title != "" {
+		return title
+	}
+
+	return typ.cfg.catalog.Translate("type %s", typ.doc.Name)
+}
+
+// CustomTitle provides the title set for the type using the //gomarkdoc:title
+// directive, or an empty string if there is none.
+func (typ *Type) CustomTitle() string {
+	return typ.cfg.typeDirectives(typ.doc.Decl).title
+}
+
+// Group provides the name of the group set for the type using the
+// //gomarkdoc:group directive, or an empty string if there is none.
+func (typ *Type) Group() string {
+	return typ.cfg.typeDirectives(typ.doc.Decl).group
 }
 
 // Location returns a representation of the node's location in a file within a
@@ -47,19 +65,32 @@ func (typ *Type) Location() Location {
 // Summary provides the one-sentence summary of the type's documentation
 // comment.
 func (typ *Type) Summary() string {
-	return extractSummary(typ.doc.Doc)
+	return extractSummary(withoutDeprecation(typ.doc.Doc))
 }
 
 // Doc provides the structured contents of the documentation comment for the
 // type.
 func (typ *Type) Doc() *Doc {
-	return NewDoc(typ.cfg.Inc(1), typ.doc.Doc)
+	return NewDoc(typ.cfg.Inc(1), withoutDeprecation(typ.doc.Doc))
+}
+
+// Deprecated reports whether the type is deprecated, i.e. its documentation
+// comment contains a paragraph starting with "Deprecated:".
+func (typ *Type) Deprecated() bool {
+	return isDeprecated(typ.doc.Doc)
+}
+
+// DeprecationNote provides the text of the deprecation paragraph of the
+// type's documentation comment without the "Deprecated:" prefix. The
+// paragraph is not part of Doc, so that it can be rendered separately.
+func (typ *Type) DeprecationNote() string {
+	return deprecationNote(typ.doc.Doc)
 }
 
 // Decl provides the raw text representation of the code for the type's
 // declaration without field comments since we print those after the type codeblock.
 func (typ *Type) Decl() (string, error) {
-	return printNode(createDeclCopyWithoutComments(typ.doc.Decl), typ.cfg.FileSet)
+	return printNode(createDeclCopyWithoutComments(typ.doc.Decl, typ.cfg.omitField), typ.cfg.FileSet)
 }
 
 // Examples lists the examples pertaining to the type from the set provided on
@@ -85,8 +116,26 @@ func (typ *Type) Examples() (examples []*Example) {
 }
 
 func (typ *Type) isSubexample(exampleName string) bool {
+	var methodNames []string
 	for _, m := range typ.doc.Methods {
-		fullName := fmt.Sprintf("%s_%s", typ.doc.Name, m.Name)
+		methodNames = append(methodNames, m.Name)
+	}
+
+	for _, f := range typ.getInterfaceMethods() {
+		if len(f.Names) > 0 {
+			methodNames = append(methodNames, f.Names[0].Name)
+		}
+	}
+
+	// Examples of struct fields are named like the examples of methods.
+	for _, f := range typ.getStructFields() {
+		if len(f.Names) > 0 {
+			methodNames = append(methodNames, f.Names[0].Name)
+		}
+	}
+
+	for _, name := range methodNames {
+		fullName := fmt.Sprintf("%s_%s", typ.doc.Name, name)
 		underscorePrefix := fmt.Sprintf("%s_", fullName)
 		if exampleName == fullName || strings.HasPrefix(exampleName, underscorePrefix) {
 			return true
@@ -96,45 +145,107 @@ func (typ *Type) isSubexample(exampleName string) bool {
 	return false
 }
 
-// Funcs lists the funcs related to the type. This only includes functions which
-// return an instance of the type or its pointer.
+// Funcs lists the funcs related to the type in the configured order. This only
+// includes functions which return an instance of the type or its pointer.
 func (typ *Type) Funcs() []*Func {
-	funcs := make([]*Func, len(typ.doc.Funcs))
-	for i, fn := range typ.doc.Funcs {
-		funcs[i] = NewFunc(typ.cfg.Inc(1), fn, typ.examples)
+	funcs := make([]*Func, 0, len(typ.doc.Funcs))
+	for _, fn := range typ.doc.Funcs {
+		if typ.cfg.omitFunc(fn) {
+			continue
+		}
+
+		funcs = append(funcs, NewFunc(typ.cfg.Inc(1), fn, typ.examples))
 	}
 
-	return funcs
+	return OrderFuncs(typ.cfg.order, funcs)
 }
 
-// Methods lists the funcs that use the type as a value or pointer receiver.
+// Methods lists the funcs that use the type as a value or pointer receiver in
+// the configured order.
 func (typ *Type) Methods() []*Func {
-	methods := make([]*Func, len(typ.doc.Methods))
-	for i, fn := range typ.doc.Methods {
-		methods[i] = NewFunc(typ.cfg.Inc(1), fn, typ.examples)
+	methods := make([]*Func, 0, len(typ.doc.Methods))
+	for _, fn := range typ.doc.Methods {
+		if typ.cfg.omitFunc(fn) {
+			continue
+		}
+
+		methods = append(methods, NewFunc(typ.cfg.Inc(1), fn, typ.examples))
 	}
 
-	return methods
+	return OrderFuncs(typ.cfg.order, methods)
 }
 
 // Consts lists the const declaration blocks containing values of this type.
 func (typ *Type) Consts() []*Value {
-	consts := make([]*Value, len(typ.doc.Consts))
-	for i, c := range typ.doc.Consts {
-		consts[i] = NewValue(typ.cfg.Inc(1), c)
+	consts := make([]*Value, 0, len(typ.doc.Consts))
+	for _, c := range typ.doc.Consts {
+		if typ.cfg.omitValue(c) {
+			continue
+		}
+
+		consts = append(consts, NewValue(typ.cfg.Inc(1), c))
 	}
 
 	return consts
 }
 
-// Fields lists the field declaration blocks of a struct type.
+// Fields lists the field declaration blocks of a struct type in the configured
+// order.
 func (typ *Type) Fields() []*Field {
 	fields := make([]*Field, len(typ.getStructFields()))
 	for i, c := range typ.getStructFields() {
-		fields[i] = NewField(typ.cfg.Inc(1), c, typ.examples)
+		fields[i] = newTypeField(typ.cfg.Inc(1), typ.doc.Name, c, typ.examples)
+	}
+
+	return OrderFields(typ.cfg.order, fields)
+}
+
+// FieldGroups lists the fields of a struct type in sections according to their
+// //gomarkdoc:group directives. The fields without a group come first in a
+// group with an empty name, followed by the groups configured using
+// ConfigWithGroupOrder and the remaining groups in the order of their first
+// appearance. Within a group, fields are listed in the configured order. The
+// fields of named groups are nested one level below the header of their group.
+func (typ *Type) FieldGroups() []*FieldGroup {
+	var (
+		groups []*FieldGroup
+		names  []string
+		byName = make(map[string]*FieldGroup)
+	)
+
+	for _, f := range typ.getStructFields() {
+		name := parseDirectives(f.Doc).group
+		group, ok := byName[name]
+		if !ok {
+			group = &FieldGroup{cfg: typ.cfg.Inc(1), name: name}
+			byName[name] = group
+			groups = append(groups, group)
+			names = append(names, name)
+		}
+
+		level := 1
+		if name != "" {
+			level = 2
+		}
+
+		group.fields = append(group.fields, newTypeField(typ.cfg.Inc(level), typ.doc.Name, f, typ.examples))
 	}
 
-	return fields
+	indices := make([]int, len(groups))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	ranks := typ.cfg.groupRanks(names, indices)
+	sort.SliceStable(groups, func(i, j int) bool {
+		return ranks[groups[i].name] < ranks[groups[j].name]
+	})
+
+	for _, g := range groups {
+		g.fields = OrderFields(typ.cfg.order, g.fields)
+	}
+
+	return groups
 }
 
 func (typ *Type) getStructFields() []*ast.Field {
@@ -145,7 +256,7 @@ func (typ *Type) getStructFields() []*ast.Field {
 			typeSpec := spec.(*ast.TypeSpec)
 			switch typeSpec.Type.(type) {
 			case *ast.StructType:
-				return typeSpec.Type.(*ast.StructType).Fields.List
+				return typ.cfg.filterFields(typeSpec.Type.(*ast.StructType).Fields.List)
 			}
 		}
 	}
@@ -170,11 +281,54 @@ func (typ *Type) IsStructType() bool {
 	return false
 }
 
+// IsInterfaceType returns true if the actual type is an interface. False
+// otherwise.
+func (typ *Type) IsInterfaceType() bool {
+	for _, spec := range typ.doc.Decl.Specs {
+		if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+			if _, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// InterfaceMethods lists the elements of an interface type in declaration
+// order. Besides methods, this includes embedded interfaces and type set
+// constraints, which can be told apart using InterfaceMethod.Kind.
+func (typ *Type) InterfaceMethods() []*InterfaceMethod {
+	fields := typ.getInterfaceMethods()
+	methods := make([]*InterfaceMethod, len(fields))
+	for i, f := range fields {
+		methods[i] = NewInterfaceMethod(typ.cfg.Inc(1), typ.doc.Name, f, typ.examples)
+	}
+
+	return methods
+}
+
+func (typ *Type) getInterfaceMethods() []*ast.Field {
+	for _, spec := range typ.doc.Decl.Specs {
+		if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+			if interfaceType, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+				return typ.cfg.filterFields(interfaceType.Methods.List)
+			}
+		}
+	}
+
+	return nil
+}
+
 // Vars lists the var declaration blocks containing values of this type.
 func (typ *Type) Vars() []*Value {
-	vars := make([]*Value, len(typ.doc.Vars))
-	for i, v := range typ.doc.Vars {
-		vars[i] = NewValue(typ.cfg.Inc(1), v)
+	vars := make([]*Value, 0, len(typ.doc.Vars))
+	for _, v := range typ.doc.Vars {
+		if typ.cfg.omitValue(v) {
+			continue
+		}
+
+		vars = append(vars, NewValue(typ.cfg.Inc(1), v))
 	}
 
 	return vars
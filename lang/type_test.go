@@ -22,6 +22,106 @@ func TestType_Examples(t *testing.T) {
 	is.Equal(ex[1].Name(), "Sub Test")
 }
 
+func TestType_Examples_fields(t *testing.T) {
+	is := is.New(t)
+
+	typ, err := loadType("../testData/lang/function", "Settings")
+	is.NoErr(err)
+
+	ex := typ.Examples()
+	is.Equal(len(ex), 1) // field examples belong to the fields
+	is.Equal(ex[0].Name(), "")
+
+	fields := typ.Fields()
+	is.Equal(len(fields), 2)
+
+	name := fields[0]
+	is.Equal(name.Doc().Blocks()[0].Spans()[0].Text(), "Name is the name of the settings.")
+	is.Equal(len(name.Doc().Blocks()), 1) // the example is not part of the documentation
+
+	ex = name.Examples()
+	is.Equal(len(ex), 3)
+
+	is.Equal(ex[0].Title(), "Example")
+	is.Equal(ex[0].Language(), "")
+	code, err := ex[0].Code()
+	is.NoErr(err)
+	is.Equal(code, "name: default")
+	is.Equal(ex[0].Location().Start.Line, 32) // the location of the field
+
+	is.Equal(ex[1].Name(), "")
+	is.Equal(ex[1].Language(), "go")
+	is.True(ex[1].HasOutput())
+	is.Equal(ex[2].Name(), "Empty")
+
+	is.Equal(len(fields[1].Examples()), 0) // ExampleReceiver documents the Receiver type
+}
+
+func TestType_Decl_nonStruct(t *testing.T) {
+	is := is.New(t)
+
+	typ, err := loadType("../testData/lang/function", "Mode")
+	is.NoErr(err)
+
+	decl, err := typ.Decl()
+	is.NoErr(err)
+	is.Equal(decl, "type Mode int")
+	is.True(!typ.IsStructType())
+}
+
+func TestType_InterfaceMethods(t *testing.T) {
+	is := is.New(t)
+
+	typ, err := loadType("../testData/lang/function", "Plugin")
+	is.NoErr(err)
+	is.True(typ.IsInterfaceType())
+	is.True(!typ.IsStructType())
+
+	decl, err := typ.Decl()
+	is.NoErr(err)
+	is.Equal(decl, "type Plugin interface {\n    fmt.Stringer\n\n    Init(name string, opts ...int) (bool, error)\n\n    Close() error\n}") // method docs are rendered separately
+
+	methods := typ.InterfaceMethods()
+	is.Equal(len(methods), 3)
+
+	is.Equal(methods[0].Kind(), lang.EmbeddedInterface)
+	is.Equal(methods[0].Name(), "fmt.Stringer")
+	is.Equal(methods[0].Summary(), "Stringer is embedded.")
+
+	is.Equal(methods[1].Kind(), lang.DeclaredMethod)
+	is.Equal(methods[1].Name(), "Init")
+	is.Equal(methods[1].Title(), "Method Init")
+	is.Equal(methods[1].Level(), typ.Level()+1)
+	is.Equal(methods[1].Summary(), "Init initializes the plugin.")
+
+	sig, err := methods[1].Signature()
+	is.NoErr(err)
+	is.Equal(sig, "func Init(name string, opts ...int) (bool, error)")
+
+	ex := methods[1].Examples()
+	is.Equal(len(ex), 1)
+	is.Equal(ex[0].Name(), "")
+	is.Equal(len(typ.Examples()), 0) // method examples don't belong to the type
+
+	sig, err = methods[2].Signature()
+	is.NoErr(err)
+	is.Equal(sig, "func Close() error")
+}
+
+func TestType_InterfaceMethods_constraint(t *testing.T) {
+	is := is.New(t)
+
+	typ, err := loadType("../testData/lang/function", "Number")
+	is.NoErr(err)
+	is.True(typ.IsInterfaceType())
+
+	methods := typ.InterfaceMethods()
+	is.Equal(len(methods), 1)
+	is.Equal(methods[0].Kind(), lang.TypeConstraint)
+	is.Equal(methods[0].Name(), "~int | ~float64")
+	is.Equal(len(methods[0].Examples()), 0)
+}
+
 func loadType(dir, name string) (*lang.Type, error) {
 	buildPkg, err := getBuildPackage(dir)
 	if err != nil {
@@ -134,7 +134,11 @@ func formatDocParagraph(paragraph string) string {
 	return mergedParagraph.String()
 }
 
-func createDeclCopyWithoutComments(from *ast.GenDecl) *ast.GenDecl {
+// createDeclCopyWithoutComments copies the declaration without the comments of
+// its struct fields and interface elements, which are documented separately.
+// Fields for which omit reports true are left out and the declaration is
+// marked as incomplete.
+func createDeclCopyWithoutComments(from *ast.GenDecl, omit func(*ast.Field) bool) *ast.GenDecl {
 	var specs []ast.Spec
 	for _, spec := range from.Specs {
 		switch spec.(type) {
@@ -148,6 +152,11 @@ func createDeclCopyWithoutComments(from *ast.GenDecl) *ast.GenDecl {
 				fields := structType.Fields
 
 				for _, field := range fields.List {
+					if omit(field) {
+						structType.Incomplete = true
+						continue
+					}
+
 					fieldCopy := copyFieldWithoutDoc(field)
 					copyFields = append(copyFields, fieldCopy)
 				}
@@ -155,6 +164,31 @@ func createDeclCopyWithoutComments(from *ast.GenDecl) *ast.GenDecl {
 				listCopy := copyFieldListWithFields(fields, copyFields)
 				structTypeCopy := copyStructTypeWithFieldList(structType, listCopy)
 				specs = append(specs, copySpecWithStructType(typeSpec, structTypeCopy))
+			case *ast.InterfaceType:
+				interfaceType := typeSpec.Type.(*ast.InterfaceType)
+
+				incomplete := interfaceType.Incomplete
+
+				var copyMethods []*ast.Field
+				for _, method := range interfaceType.Methods.List {
+					if omit(method) {
+						incomplete = true
+						continue
+					}
+
+					copyMethods = append(copyMethods, copyFieldWithoutDoc(method))
+				}
+
+				interfaceTypeCopy := &ast.InterfaceType{
+					Interface:  interfaceType.Interface,
+					Methods:    copyFieldListWithFields(interfaceType.Methods, copyMethods),
+					Incomplete: incomplete,
+				}
+				specs = append(specs, copySpecWithType(typeSpec, interfaceTypeCopy))
+			default:
+				// Only struct and interface declarations contain comments for
+				// their elements. All other types are kept as they are.
+				specs = append(specs, typeSpec)
 			}
 		}
 	}
@@ -174,6 +208,10 @@ func copyGenDeclWithSpecs(decl *ast.GenDecl, specs []ast.Spec) *ast.GenDecl {
 }
 
 func copySpecWithStructType(spec *ast.TypeSpec, typeSpec *ast.StructType) *ast.TypeSpec {
+	return copySpecWithType(spec, typeSpec)
+}
+
+func copySpecWithType(spec *ast.TypeSpec, typeSpec ast.Expr) *ast.TypeSpec {
 	return &ast.TypeSpec{
 		Doc:        spec.Doc,
 		Name:       spec.Name,
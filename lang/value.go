@@ -31,13 +31,26 @@ func (v *Value) Location() Location {
 // Summary provides the one-sentence summary of the value's documentation
 // comment.
 func (v *Value) Summary() string {
-	return extractSummary(v.doc.Doc)
+	return extractSummary(withoutDeprecation(v.doc.Doc))
 }
 
 // Doc provides the structured contents of the documentation comment for the
 // example.
 func (v *Value) Doc() *Doc {
-	return NewDoc(v.cfg.Inc(1), v.doc.Doc)
+	return NewDoc(v.cfg.Inc(1), withoutDeprecation(v.doc.Doc))
+}
+
+// Deprecated reports whether the value is deprecated, i.e. its documentation
+// comment contains a paragraph starting with "Deprecated:".
+func (v *Value) Deprecated() bool {
+	return isDeprecated(v.doc.Doc)
+}
+
+// DeprecationNote provides the text of the deprecation paragraph of the
+// value's documentation comment without the "Deprecated:" prefix. The
+// paragraph is not part of Doc, so that it can be rendered separately.
+func (v *Value) DeprecationNote() string {
+	return deprecationNote(v.doc.Doc)
 }
 
 // Decl provides the raw text representation of the code for declaring the const
@@ -34,21 +34,11 @@ func Build() error {
 }
 
 func Doc() error {
-	return shellcmd.RunAll(
-		`go run ./cmd/gomarkdoc .`,
-		`go run ./cmd/gomarkdoc --header "" ./lang/...`,
-		`go run ./cmd/gomarkdoc --header "" ./format/...`,
-		`go run ./cmd/gomarkdoc --header "" ./cmd/...`,
-	)
+	return shellcmd.Command(`go run ./cmd/gomarkdoc`).Run()
 }
 
 func DocVerify() error {
-	return shellcmd.RunAll(
-		`go run ./cmd/gomarkdoc -c .`,
-		`go run ./cmd/gomarkdoc -c --header "" ./lang/...`,
-		`go run ./cmd/gomarkdoc -c --header "" ./format/...`,
-		`go run ./cmd/gomarkdoc -c --header "" ./cmd/...`,
-	)
+	return shellcmd.Command(`go run ./cmd/gomarkdoc -c`).Run()
 }
 
 func RegenerateTestDocs() error {
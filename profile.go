@@ -0,0 +1,77 @@
+package gomarkdoc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//go:generate ./gentmpl.sh fullTemplates templates_full ./templates/full
+
+const (
+	// ProfileStructs is the default profile. It documents only the struct and
+	// interface types of a package along with their fields and methods, which
+	// is suited for configuration references.
+	ProfileStructs = "structs"
+
+	// ProfileFull documents every symbol of a package, including constants,
+	// variables, functions, all types and their methods, with an index section
+	// for each kind of symbol.
+	ProfileFull = "full"
+)
+
+// profiles holds the templates of each profile which differ from the default
+// templates.
+var profiles = map[string]map[string]string{
+	ProfileStructs: {},
+	ProfileFull:    fullTemplates,
+}
+
+// Profiles lists the names of the built-in template profiles in alphabetical
+// order.
+func Profiles() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// ProfileTemplates provides a copy of the built-in templates of the profile
+// with the provided name, keyed by the template name. Every profile defines
+// the same set of templates as DefaultTemplates.
+func ProfileTemplates(profile string) (map[string]string, error) {
+	changed, ok := profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf(
+			"gomarkdoc: invalid profile %q. Valid options: %s",
+			profile,
+			strings.Join(Profiles(), ", "),
+		)
+	}
+
+	tmpls := DefaultTemplates()
+	for name, tmpl := range changed {
+		tmpls[name] = tmpl
+	}
+
+	return tmpls, nil
+}
+
+// WithProfile changes the renderer to use the built-in templates of the
+// profile with the provided name instead of the default ones. Template
+// overrides take precedence over the templates of the profile.
+func WithProfile(profile string) RendererOption {
+	return func(renderer *Renderer) error {
+		if _, err := ProfileTemplates(profile); err != nil {
+			return err
+		}
+
+		renderer.profile = profile
+
+		return nil
+	}
+}
@@ -0,0 +1,121 @@
+package gomarkdoc_test
+
+import (
+	"go/ast"
+	"go/doc"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/cloudogu/gomarkdoc"
+	"github.com/cloudogu/gomarkdoc/format"
+	"github.com/cloudogu/gomarkdoc/lang"
+)
+
+func TestWithProfile(t *testing.T) {
+	is := is.New(t)
+
+	pkg := parsePackage(t, `// Package example has every kind of symbol.
+package example
+
+// Limit is a constant.
+const Limit = 5
+
+// Enabled is a variable.
+var Enabled = true
+
+// Config is a struct.
+type Config struct {
+	// Name is a field.
+	Name string
+}
+
+// Validate is a method.
+func (c Config) Validate() error { return nil }
+
+// Mode is not a struct.
+type Mode int
+
+// Plugin is an interface.
+type Plugin interface {
+	// Init initializes the plugin.
+	Init() error
+}
+
+// Run is a function.
+func Run() {}
+`)
+
+	structs, err := gomarkdoc.NewRenderer(gomarkdoc.WithFormat(&format.PlainMarkdown{}))
+	is.NoErr(err)
+
+	out, err := structs.Package(pkg)
+	is.NoErr(err)
+	is.True(strings.Contains(out, "type Config"))
+	is.True(strings.Contains(out, "type Plugin"))
+	is.True(strings.Contains(out, "Init initializes the plugin.")) // interface methods are documented
+	is.True(!strings.Contains(out, "type Mode"))                   // only structs and interfaces by default
+	is.True(!strings.Contains(out, "func Run"))
+
+	full, err := gomarkdoc.NewRenderer(
+		gomarkdoc.WithProfile(gomarkdoc.ProfileFull),
+		gomarkdoc.WithFormat(&format.PlainMarkdown{}),
+	)
+	is.NoErr(err)
+	is.NoErr(full.Validate())
+
+	out, err = full.Package(pkg)
+	is.NoErr(err)
+
+	for _, s := range []string{
+		"## Constants", "const Limit = 5",
+		"## Variables", "var Enabled = true",
+		"## Functions", "func Run()",
+		"## Types", "type Config struct", "type Mode int", "func (c Config) Validate() error",
+		"### Name", // struct fields are still documented
+		"### Init", "func Init() error",
+	} {
+		is.True(strings.Contains(out, s)) // full profile contains every symbol
+	}
+}
+
+func TestProfileTemplates(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(gomarkdoc.Profiles(), []string{gomarkdoc.ProfileFull, gomarkdoc.ProfileStructs})
+
+	structs, err := gomarkdoc.ProfileTemplates(gomarkdoc.ProfileStructs)
+	is.NoErr(err)
+	is.Equal(structs, gomarkdoc.DefaultTemplates())
+
+	full, err := gomarkdoc.ProfileTemplates(gomarkdoc.ProfileFull)
+	is.NoErr(err)
+	is.Equal(len(full), len(structs)) // profiles define the same templates
+	is.True(full["package"] != structs["package"])
+
+	_, err = gomarkdoc.ProfileTemplates("unknown")
+	is.Equal(err.Error(), `gomarkdoc: invalid profile "unknown". Valid options: full, structs`)
+
+	_, err = gomarkdoc.NewRenderer(gomarkdoc.WithProfile("unknown"))
+	is.True(err != nil)
+}
+
+func parsePackage(t *testing.T, src string, opts ...any) *lang.Package {
+	t.Helper()
+
+	fs := token.NewFileSet()
+	f, err := parser.ParseFile(fs, "example.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docPkg, err := doc.NewFromFiles(fs, []*ast.File{f}, "example.com/example", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return lang.NewPackage(&lang.Config{FileSet: fs, Level: 1}, docPkg, nil)
+}
@@ -2,6 +2,8 @@ package gomarkdoc
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"text/template"
@@ -12,11 +14,16 @@ import (
 
 type (
 	// Renderer provides capabilities for rendering various types of
-	// documentation with the configured format and templates.
+	// documentation with the configured format and templates. Once created, a
+	// Renderer is safe for concurrent use by multiple goroutines.
 	Renderer struct {
-		templateOverrides map[string]string
-		tmpl              *template.Template
-		format            format.Format
+		templateOverrides   map[string]string
+		additionalTemplates map[string]string
+		templateFuncs       template.FuncMap
+		profile             string
+		catalog             *lang.Catalog
+		tmpl                *template.Template
+		format              format.Format
 	}
 
 	// RendererOption configures the renderer's behavior.
@@ -25,13 +32,19 @@ type (
 
 //go:generate ./gentmpl.sh templates templates
 
+// templateExt is the file extension used for template files.
+const templateExt = ".gotxt"
+
 // NewRenderer initializes a Renderer configured using the provided options. If
 // nothing special is provided, the created renderer will use the default set of
 // templates and the GitHubFlavoredMarkdown.
 func NewRenderer(opts ...RendererOption) (*Renderer, error) {
 	renderer := &Renderer{
-		templateOverrides: make(map[string]string),
-		format:            &format.GitHubFlavoredMarkdown{},
+		templateOverrides:   make(map[string]string),
+		additionalTemplates: make(map[string]string),
+		templateFuncs:       make(template.FuncMap),
+		profile:             ProfileStructs,
+		format:              &format.GitHubFlavoredMarkdown{},
 	}
 
 	for _, opt := range opts {
@@ -40,7 +53,12 @@ func NewRenderer(opts ...RendererOption) (*Renderer, error) {
 		}
 	}
 
-	for name, tmplStr := range templates {
+	base, err := ProfileTemplates(renderer.profile)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, tmplStr := range base {
 		// Use the override if present
 		if val, ok := renderer.templateOverrides[name]; ok {
 			tmplStr = val
@@ -97,6 +115,9 @@ func NewRenderer(opts ...RendererOption) (*Renderer, error) {
 				},
 
 				"bold":                renderer.format.Bold,
+				"italic":              renderer.format.Italic,
+				"codeSpan":            renderer.format.CodeSpan,
+				"strikethrough":       renderer.format.Strikethrough,
 				"header":              renderer.format.Header,
 				"rawHeader":           renderer.format.RawHeader,
 				"codeBlock":           renderer.format.CodeBlock,
@@ -108,22 +129,50 @@ func NewRenderer(opts ...RendererOption) (*Renderer, error) {
 				"localHref":           renderer.format.LocalHref,
 				"codeHref":            renderer.format.CodeHref,
 				"paragraph":           renderer.format.Paragraph,
+				"callout":             renderer.format.Callout,
 				"escape":              renderer.format.Escape,
+				"tr":                  renderer.catalog.Translate,
 			})
 
+			tmpl.Funcs(libraryFuncs())
+
+			// Functions provided by the user take precedence over the
+			// built-in ones.
+			tmpl.Funcs(renderer.templateFuncs)
+
 			if _, err := tmpl.Parse(tmplStr); err != nil {
-				return nil, err
+				return nil, newTemplateError(name, err)
 			}
 
 			renderer.tmpl = tmpl
 		} else if _, err := renderer.tmpl.New(name).Parse(tmplStr); err != nil {
-			return nil, err
+			return nil, newTemplateError(name, err)
+		}
+	}
+
+	// Additional templates are parsed last so that they can make use of the
+	// same functions as the built-in ones.
+	for name, tmplStr := range renderer.additionalTemplates {
+		if _, err := renderer.tmpl.New(name).Parse(tmplStr); err != nil {
+			return nil, newTemplateError(name, err)
 		}
 	}
 
 	return renderer, nil
 }
 
+// DefaultTemplates provides a copy of the built-in templates of the default
+// profile, keyed by the template name. They are a good starting point for
+// custom templates provided through WithTemplateOverride.
+func DefaultTemplates() map[string]string {
+	tmpls := make(map[string]string, len(templates))
+	for name, tmpl := range templates {
+		tmpls[name] = tmpl
+	}
+
+	return tmpls
+}
+
 // WithTemplateOverride adds a template that overrides the template with the
 // provided name using the value provided in the tmpl parameter.
 func WithTemplateOverride(name, tmpl string) RendererOption {
@@ -138,6 +187,98 @@ func WithTemplateOverride(name, tmpl string) RendererOption {
 	}
 }
 
+// WithAdditionalTemplate adds a template with the provided name that is not
+// part of the built-in templates. The template can be referenced from any other
+// template using the template action or the include function, which makes it
+// possible to define reusable fragments for custom templates. To replace one of
+// the built-in templates, use WithTemplateOverride instead.
+func WithAdditionalTemplate(name, tmpl string) RendererOption {
+	return func(renderer *Renderer) error {
+		if _, ok := templates[name]; ok {
+			return fmt.Errorf(`gomarkdoc: template name "%s" is a built-in template and must be overridden instead`, name)
+		}
+
+		renderer.additionalTemplates[name] = tmpl
+
+		return nil
+	}
+}
+
+// WithTemplateDir loads every file with the .gotxt extension in the provided
+// directory as a template named after the file without its extension. Files
+// matching the name of a built-in template override it, as with
+// WithTemplateOverride. All other files are added as additional templates, as
+// with WithAdditionalTemplate.
+func WithTemplateDir(dir string) RendererOption {
+	return func(renderer *Renderer) error {
+		tmpls, err := ReadTemplateDir(dir)
+		if err != nil {
+			return err
+		}
+
+		for name, tmpl := range tmpls {
+			if _, ok := templates[name]; ok {
+				renderer.templateOverrides[name] = tmpl
+			} else {
+				renderer.additionalTemplates[name] = tmpl
+			}
+		}
+
+		return nil
+	}
+}
+
+// ReadTemplateDir reads every file with the .gotxt extension in the provided
+// directory and returns the contents keyed by the name of the file without its
+// extension. Subdirectories are not traversed.
+func ReadTemplateDir(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: couldn't read template directory %s: %w", dir, err)
+	}
+
+	tmpls := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != templateExt {
+			continue
+		}
+
+		b, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: couldn't read template file %s: %w", entry.Name(), err)
+		}
+
+		tmpls[strings.TrimSuffix(entry.Name(), templateExt)] = string(b)
+	}
+
+	return tmpls, nil
+}
+
+// WithTemplateFuncs adds the provided functions to the set of functions
+// available to all templates. Functions with the same name as one of the
+// built-in functions replace it.
+func WithTemplateFuncs(funcs template.FuncMap) RendererOption {
+	return func(renderer *Renderer) error {
+		for name, fn := range funcs {
+			renderer.templateFuncs[name] = fn
+		}
+
+		return nil
+	}
+}
+
+// WithCatalog translates the text generated by the templates, such as the
+// headings of sections, using the provided catalog. Templates access the
+// catalog through the tr function. The same catalog should be provided to the
+// packages being rendered (see lang.PackageWithCatalog), so that the titles of
+// symbols match the headings and links generated by the templates.
+func WithCatalog(catalog *lang.Catalog) RendererOption {
+	return func(renderer *Renderer) error {
+		renderer.catalog = catalog
+		return nil
+	}
+}
+
 // WithFormat changes the renderer to use the format provided instead of the
 // default format.
 func WithFormat(format format.Format) RendererOption {
@@ -154,6 +295,20 @@ func (out *Renderer) File(file *lang.File) (string, error) {
 	return out.writeTemplate("file", file)
 }
 
+// ModIndex renders an index of packages documented in separate files to a
+// string. You can change the rendering of the index by overriding the
+// "modindex" template.
+func (out *Renderer) ModIndex(index *lang.ModIndex) (string, error) {
+	return out.writeTemplate("modindex", index)
+}
+
+// APIDiff renders a report of the changes to the documented API of packages
+// between two revisions to a string. You can change the rendering of the
+// report by overriding the "apidiff" template.
+func (out *Renderer) APIDiff(diff *lang.APIDiff) (string, error) {
+	return out.writeTemplate("apidiff", diff)
+}
+
 // Package renders a package's documentation to a string. You can change the
 // rendering of the package by overriding the "package" template or one of the
 // templates it references.
@@ -182,6 +337,18 @@ func (out *Renderer) Example(ex *lang.Example) (string, error) {
 	return out.writeTemplate("example", ex)
 }
 
+// Template renders the template with the provided name using the provided data
+// object to a string. The name may refer to a built-in template or to one added
+// with WithAdditionalTemplate or WithTemplateDir. The data has to match what
+// the template expects, e.g. a *lang.Type for the "type" template.
+func (out *Renderer) Template(name string, data any) (string, error) {
+	if out.tmpl.Lookup(name) == nil {
+		return "", fmt.Errorf(`gomarkdoc: unknown template "%s"`, name)
+	}
+
+	return out.writeTemplate(name, data)
+}
+
 // writeTemplate renders the template of the provided name using the provided
 // data object to a string. It uses the set of templates provided to the
 // renderer as a template library.
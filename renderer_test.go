@@ -0,0 +1,296 @@
+package gomarkdoc_test
+
+import (
+	"go/doc"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/cloudogu/gomarkdoc"
+	"github.com/cloudogu/gomarkdoc/format"
+	"github.com/cloudogu/gomarkdoc/lang"
+)
+
+func TestWithTemplateDir(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	writeTemplate(t, dir, "file.gotxt", `{{- range .Packages -}}{{ include "greeting" .Name }}{{- end -}}`)
+	writeTemplate(t, dir, "greeting.gotxt", `Hello {{ . }}`)
+	writeTemplate(t, dir, "ignored.txt", `{{ .Invalid`)
+
+	r, err := gomarkdoc.NewRenderer(gomarkdoc.WithTemplateDir(dir))
+	is.NoErr(err)
+
+	pkg := lang.NewPackage(&lang.Config{}, newDocPackage("example"), nil)
+	out, err := r.File(lang.NewFile("", "", []*lang.Package{pkg}))
+	is.NoErr(err)
+	is.Equal(out, "Hello example")
+}
+
+func TestWithTemplateDir_missing(t *testing.T) {
+	is := is.New(t)
+
+	_, err := gomarkdoc.NewRenderer(gomarkdoc.WithTemplateDir(filepath.Join(t.TempDir(), "missing")))
+	is.True(err != nil)
+}
+
+func TestWithAdditionalTemplate(t *testing.T) {
+	is := is.New(t)
+
+	_, err := gomarkdoc.NewRenderer(gomarkdoc.WithAdditionalTemplate("type", "{{ .Name }}"))
+	is.Equal(err.Error(), `gomarkdoc: template name "type" is a built-in template and must be overridden instead`)
+
+	r, err := gomarkdoc.NewRenderer(
+		gomarkdoc.WithAdditionalTemplate("name", "{{ .Name }}"),
+		gomarkdoc.WithTemplateOverride("package", `{{ template "name" . }}!`),
+	)
+	is.NoErr(err)
+
+	out, err := r.Package(lang.NewPackage(&lang.Config{}, newDocPackage("example"), nil))
+	is.NoErr(err)
+	is.Equal(out, "example!")
+}
+
+func TestRenderer_deprecated(t *testing.T) {
+	is := is.New(t)
+
+	pkg := parsePackage(t, `// Package example has deprecated symbols.
+package example
+
+// Config is a struct.
+//
+// Deprecated: Use Settings instead.
+type Config struct {
+	// Deprecated: Name is ignored.
+	Name string
+}
+`)
+
+	out, err := gomarkdoc.NewRenderer()
+	is.NoErr(err)
+
+	text, err := out.Package(pkg)
+	is.NoErr(err)
+	is.True(strings.Contains(text, "- ~~[type Config](<#type-config>)~~"))
+	is.True(strings.Contains(text, "> [!WARNING]\n> **Deprecated:** Use Settings instead.\n\nConfig is a struct."))
+	is.True(strings.Contains(text, "### Name\n\n> [!WARNING]\n> **Deprecated:** Name is ignored."))
+}
+
+func TestRenderer_directives(t *testing.T) {
+	is := is.New(t)
+
+	// The doc comments of declarations are only kept with PreserveAST when
+	// using the doc package directly.
+	pkg := parsePackage(t, `// Package example has directives.
+package example
+
+// Config is a struct.
+//
+//gomarkdoc:title "The Config"
+type Config struct {
+	// Name is the name.
+	Name string
+
+	// Addr is the address.
+	//
+	//gomarkdoc:group Network
+	//gomarkdoc:title "Listen address"
+	Addr string
+
+	// Debug enables debug output.
+	//
+	//gomarkdoc:hide
+	Debug bool
+}
+
+// Internal is hidden.
+//
+//gomarkdoc:hide
+func Internal() {}
+`, doc.PreserveAST)
+
+	out, err := gomarkdoc.NewRenderer()
+	is.NoErr(err)
+
+	text, err := out.Package(pkg)
+	is.NoErr(err)
+	is.True(strings.Contains(text, "- [The Config](<#the-config>)"))
+	is.True(strings.Contains(text, "## The Config\n\nConfig is a struct.\n\n```go"))
+	is.True(strings.Contains(text, "### Name\n\nName is the name.\n\n### Network\n\n#### Listen address\n\nAddr is the address."))
+	is.True(!strings.Contains(text, "Debug"))
+	is.True(!strings.Contains(text, "Internal"))
+}
+
+func TestRenderer_fieldExamples(t *testing.T) {
+	is := is.New(t)
+
+	pkg := parsePackage(t, `// Package example has field examples.
+package example
+
+// Config is a struct.
+type Config struct {
+	// Name is the name.
+	//
+	// Example:
+	//
+	//	name: default
+	Name string
+
+	// Example: without documentation.
+	//
+	//	port: 80
+	Port int
+}
+`)
+
+	out, err := gomarkdoc.NewRenderer()
+	is.NoErr(err)
+
+	text, err := out.Package(pkg)
+	is.NoErr(err)
+	is.True(strings.Contains(text, "### Name\n\nName is the name.\n\n<details><summary>Example</summary>"))
+	is.True(strings.Contains(text, "```\nname: default\n```"))
+	is.True(strings.Contains(text, "### Port\n\n<details><summary>Example</summary>\n<p>\n\nwithout documentation.\n\n```\nport: 80\n```"))
+}
+
+func TestRenderer_callout(t *testing.T) {
+	is := is.New(t)
+
+	pkg := parsePackage(t, `// Package example has callouts.
+//
+// Note: Read this first.
+//
+// Security: Never log secrets.
+package example
+`)
+
+	out, err := gomarkdoc.NewRenderer()
+	is.NoErr(err)
+
+	text, err := out.Package(pkg)
+	is.NoErr(err)
+	is.True(strings.Contains(text, "> [!NOTE]\n> Read this first.\n\n> [!CAUTION]\n> **Security:** Never log secrets."))
+}
+
+func TestRenderer_asideCallouts(t *testing.T) {
+	is := is.New(t)
+
+	pkg := parsePackage(t, `// Package example has callouts.
+//
+// Note: Read this first.
+//
+// Security: Never log secrets.
+package example
+`)
+
+	out, err := gomarkdoc.NewRenderer(gomarkdoc.WithFormat(&format.AsideCallouts{Format: &format.GitHubFlavoredMarkdown{}}))
+	is.NoErr(err)
+
+	text, err := out.Package(pkg)
+	is.NoErr(err)
+	is.True(strings.Contains(text, "<aside class=\"note\">\n\n**Note:** Read this first.\n\n</aside>\n\n"+
+		"<aside class=\"security\">\n\n**Security:** Never log secrets.\n\n</aside>"))
+}
+
+func TestRenderer_spans(t *testing.T) {
+	is := is.New(t)
+
+	pkg := parsePackage(t, `// Package example uses the *config* from [the spec], see `+"`my_func`"+`
+// (https://example.com/a_b).
+//
+// [the spec]: https://example.com/spec_v1
+package example
+`)
+
+	out, err := gomarkdoc.NewRenderer()
+	is.NoErr(err)
+
+	text, err := out.Package(pkg)
+	is.NoErr(err)
+	is.True(strings.Contains(text, "Package example uses the \\*config\\* from [the spec](<https://example.com/spec_v1>), see `my_func` \\([https://example.com/a_b](<https://example.com/a_b>)\\)."))
+}
+
+func TestWithCatalog(t *testing.T) {
+	is := is.New(t)
+
+	catalog, err := lang.NewCatalog("de", map[string]string{"Index": "Übersicht"})
+	is.NoErr(err)
+
+	out, err := gomarkdoc.NewRenderer(
+		gomarkdoc.WithCatalog(catalog),
+		gomarkdoc.WithTemplateOverride("index", `{{ tr "type %s" .Name }}`),
+	)
+	is.NoErr(err)
+
+	text, err := out.Package(parsePackage(t, "// Package example is translated.\npackage example\n"))
+	is.NoErr(err)
+	is.True(strings.Contains(text, "## Übersicht\n\nTyp example"))
+}
+
+func TestRenderer_Template(t *testing.T) {
+	is := is.New(t)
+
+	out, err := gomarkdoc.NewRenderer(
+		gomarkdoc.WithAdditionalTemplate("names", `{{ range .Types }}{{ .Name }} {{ end }}`),
+	)
+	is.NoErr(err)
+
+	pkg := parsePackage(t, "package example\n\ntype A struct{}\n\ntype B struct{}\n")
+
+	text, err := out.Template("names", pkg)
+	is.NoErr(err)
+	is.Equal(text, "A B ")
+
+	_, err = out.Template("unknown", pkg)
+	is.True(err != nil)
+	is.Equal(err.Error(), `gomarkdoc: unknown template "unknown"`)
+}
+
+func TestRenderer_ModIndex(t *testing.T) {
+	is := is.New(t)
+
+	out, err := gomarkdoc.NewRenderer()
+	is.NoErr(err)
+
+	pkg := parsePackage(t, `// Package example is indexed.
+package example
+
+// Config is a struct.
+type Config struct{}
+
+// Num is not listed by the default profile.
+type Num int
+`)
+
+	index := lang.NewModIndex("header", "", []*lang.ModIndexEntry{{Package: pkg, Href: "example/README.md"}})
+
+	text, err := out.ModIndex(index)
+	is.NoErr(err)
+	is.True(strings.HasPrefix(text, "header\n\n# Packages"))
+	is.True(strings.Contains(text, "## [example.com/example](<example/README.md>)\n\nPackage example is indexed."))
+	is.True(strings.Contains(text, "- [type Config](<example/README.md#type-config>)"))
+	is.True(!strings.Contains(text, "type Num"))
+
+	out, err = gomarkdoc.NewRenderer(gomarkdoc.WithTemplateOverride("modindex", `{{ range .Packages }}{{ .Href }} {{ end }}`))
+	is.NoErr(err)
+
+	text, err = out.ModIndex(index)
+	is.NoErr(err)
+	is.Equal(text, "example/README.md ")
+}
+
+func writeTemplate(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0664); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newDocPackage(name string) *doc.Package {
+	return &doc.Package{Name: name}
+}
@@ -3,9 +3,106 @@
 package gomarkdoc
 
 var templates = map[string]string{
+	"apidiff": `{{if .Header -}}
+	{{- .Header -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- header 1 (tr "API changes from %s to %s" .From .To) -}}
+
+{{- if not .Packages -}}
+	{{- spacer -}}
+	{{- tr "The documented API did not change." -}}
+{{- end -}}
+
+{{- range .Packages -}}
+	{{- spacer -}}
+	{{- header 2 (tr "package %s" .ImportPath) -}}
+
+	{{- if eq .Kind "added" -}}
+		{{- spacer -}}
+		{{- tr "The package was added." -}}
+	{{- else if eq .Kind "removed" -}}
+		{{- spacer -}}
+		{{- tr "The package was removed." -}}
+	{{- else if .DocChanged -}}
+		{{- spacer -}}
+		{{- tr "The documentation of the package changed." -}}
+	{{- end -}}
+
+	{{- range .Types -}}
+		{{- spacer -}}
+		{{- header 3 (tr "type %s" .Name) -}}
+
+		{{- if eq .Kind "added" -}}
+			{{- spacer -}}
+			{{- tr "The type was added." -}}
+		{{- else if eq .Kind "removed" -}}
+			{{- spacer -}}
+			{{- tr "The type was removed." -}}
+		{{- end -}}
+
+		{{- if .DocChanged -}}
+			{{- spacer -}}
+			{{- tr "The documentation of the type changed." -}}
+		{{- end -}}
+
+		{{- if .DeclChanged -}}
+			{{- spacer -}}
+			{{- tr "The declaration changed from:" -}}
+			{{- spacer -}}
+			{{- codeBlock "go" .OldDecl -}}
+			{{- spacer -}}
+			{{- tr "to:" -}}
+			{{- spacer -}}
+			{{- codeBlock "go" .NewDecl -}}
+		{{- end -}}
+
+		{{- range $i, $field := .Fields -}}
+			{{- if eq $i 0 -}}{{- spacer -}}{{- else -}}{{- inlineSpacer -}}{{- end -}}
+			{{- $name := codeSpan .Name -}}
+			{{- if eq .Kind "added" -}}
+				{{- if .NewTag -}}
+					{{- listEntry 0 (tr "Field %s of type %s with tag %s was added." $name (codeSpan .NewType) (codeSpan .NewTag)) -}}
+				{{- else -}}
+					{{- listEntry 0 (tr "Field %s of type %s was added." $name (codeSpan .NewType)) -}}
+				{{- end -}}
+			{{- else if eq .Kind "removed" -}}
+				{{- listEntry 0 (tr "Field %s was removed." $name) -}}
+			{{- else -}}
+				{{- $text := "" -}}
+				{{- if .TypeChanged -}}
+					{{- $text = tr "The type of field %s changed from %s to %s." $name (codeSpan .OldType) (codeSpan .NewType) -}}
+				{{- end -}}
+				{{- if .TagChanged -}}
+					{{- $tag := tr "The tag of field %s changed from %s to %s." $name (codeSpan .OldTag) (codeSpan .NewTag) -}}
+					{{- if not .OldTag -}}{{- $tag = tr "The tag %s was added to field %s." (codeSpan .NewTag) $name -}}{{- end -}}
+					{{- if not .NewTag -}}{{- $tag = tr "The tag %s was removed from field %s." (codeSpan .OldTag) $name -}}{{- end -}}
+					{{- $text = printf "%s %s" $text $tag -}}
+				{{- end -}}
+				{{- if .DocChanged -}}
+					{{- $text = printf "%s %s" $text (tr "The documentation of field %s changed." $name) -}}
+				{{- end -}}
+				{{- listEntry 0 (trim $text) -}}
+			{{- end -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- spacer -}}
+
+{{- if .Footer -}}
+	{{- .Footer -}}
+	{{- spacer -}}
+{{- end -}}
+`,
+	"deprecation": `{{- callout "deprecated" (escape .DeprecationNote) -}}
+`,
 	"doc": `{{- range (iter .Blocks) -}}
 	{{- if eq .Entry.Kind "paragraph" -}}
-		{{- paragraph .Entry.Text -}}
+		{{- include "spans" .Entry.Spans | paragraph -}}
+	{{- else if eq .Entry.Kind "callout" -}}
+		{{- include "spans" .Entry.Spans | callout .Entry.Callout -}}
 	{{- else if eq .Entry.Kind "code" -}}
 		{{- codeBlock "" .Entry.Text -}}
 	{{- else if eq .Entry.Kind "header" -}}
@@ -23,12 +120,12 @@ var templates = map[string]string{
 {{- template "doc" .Doc -}}
 {{- spacer -}}
 
-{{- codeBlock "go" .Code -}}
+{{- codeBlock .Language .Code -}}
 {{- spacer -}}
 
 {{- if .HasOutput -}}
 
-	{{- header 4 "Output" -}}
+	{{- header 4 (tr "Output") -}}
 	{{- spacer -}}
 
 	{{- codeBlock "" .Output -}}
@@ -54,16 +151,23 @@ var templates = map[string]string{
 	{{- spacer -}}
 {{- end -}}
 `,
-	"func": `{{- if .Receiver -}}
-	{{- codeHref .Location | link (escape .Name) | printf "func \\(%s\\) %s" (escape .Receiver) | rawHeader .Level -}}
+	"func": `{{- if .CustomTitle -}}
+	{{- header .Level .CustomTitle -}}
+{{- else if .Receiver -}}
+	{{- codeHref .Location | link (escape .Name) | tr "func (%s) %s" (escape .Receiver) | rawHeader .Level -}}
 {{- else -}}
-	{{- codeHref .Location | link (escape .Name) | printf "func %s" | rawHeader .Level -}}
+	{{- codeHref .Location | link (escape .Name) | tr "func %s" | rawHeader .Level -}}
 {{- end -}}
 {{- spacer -}}
 
 {{- codeBlock "go" .Signature -}}
 {{- spacer -}}
 
+{{- if .Deprecated -}}
+	{{- template "deprecation" . -}}
+	{{- if len .Doc.Blocks -}}{{- spacer -}}{{- end -}}
+{{- end -}}
+
 {{- template "doc" .Doc -}}
 
 {{- if len .Examples -}}
@@ -78,11 +182,42 @@ var templates = map[string]string{
 `,
 	"import": `{{- codeBlock "go" .Import -}}`,
 	"index": `{{- range .Types -}}
-    {{- if .IsStructType -}}
-        {{- codeHref .Location | link (escape .Name) | printf "type %s" | localHref | link .Title | listEntry 0 -}}
+    {{- if or .IsStructType .IsInterfaceType -}}
+        {{- $entry := codeHref .Location | link (escape .Name) | tr "type %s" | localHref | link .Title -}}
+        {{- if .CustomTitle -}}{{- $entry = localHref .CustomTitle | link (escape .CustomTitle) -}}{{- end -}}
+        {{- if .Deprecated -}}{{- $entry = strikethrough $entry -}}{{- end -}}
+        {{- listEntry 0 $entry -}}
         {{- inlineSpacer -}}
     {{- end -}}
 {{- end -}}
+`,
+	"interfacemethod": `{{- if .CustomTitle -}}
+	{{- header .Level .CustomTitle -}}
+{{- else -}}
+	{{- header .Level .Name -}}
+{{- end -}}
+{{- spacer -}}
+
+{{- if eq .Kind "method" -}}
+	{{- codeBlock "go" .Signature -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- if .Deprecated -}}
+	{{- template "deprecation" . -}}
+	{{- if len .Doc.Blocks -}}{{- spacer -}}{{- end -}}
+{{- end -}}
+
+{{- template "doc" .Doc -}}
+
+{{- if len .Examples -}}
+	{{- spacer -}}
+
+	{{- range (iter .Examples) -}}
+		{{- template "example" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
 `,
 	"list": `{{- range (iter .Items) -}}
     {{- if eq .Entry.Kind "ordered" -}}
@@ -100,9 +235,50 @@ var templates = map[string]string{
     {{- end -}}
 
 {{- end -}}`,
+	"modindex": `{{if .Header -}}
+	{{- .Header -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- header 1 (tr "Packages") -}}
+
+{{- range .Packages -}}
+	{{- $href := .Href -}}
+	{{- spacer -}}
+	{{- link (escape .ImportPath) $href | rawHeader 2 -}}
+
+	{{- if .Summary -}}
+		{{- spacer -}}
+		{{- escape .Summary -}}
+	{{- end -}}
+
+	{{- $first := true -}}
+	{{- range .Types -}}
+		{{- if or .IsStructType .IsInterfaceType -}}
+			{{- if $first -}}{{- spacer -}}{{- $first = false -}}{{- else -}}{{- inlineSpacer -}}{{- end -}}
+			{{- $entry := codeHref .Location | link (escape .Name) | tr "type %s" | localHref | printf "%s%s" $href | link .Title -}}
+			{{- if .CustomTitle -}}{{- $entry = localHref .CustomTitle | printf "%s%s" $href | link (escape .CustomTitle) -}}{{- end -}}
+			{{- if .Deprecated -}}{{- $entry = strikethrough $entry -}}{{- end -}}
+			{{- listEntry 0 $entry -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- spacer -}}
+
+{{- if .Footer -}}
+	{{- .Footer -}}
+	{{- spacer -}}
+{{- end -}}
+`,
 	"package": `{{- header .Level .Title -}}
 {{- spacer -}}
 
+{{- if .Deprecated -}}
+	{{- template "deprecation" . -}}
+	{{- spacer -}}
+{{- end -}}
+
 {{- if len .Doc.Blocks -}}
 	{{- template "doc" .Doc -}}
 	{{- spacer -}}
@@ -113,7 +289,7 @@ var templates = map[string]string{
 	{{- spacer -}}
 {{- end -}}
 
-{{- header (add .Level 1) "Index" -}}
+{{- header (add .Level 1) (tr "Index") -}}
 {{- spacer -}}
 
 {{- template "index" . -}}
@@ -127,32 +303,86 @@ var templates = map[string]string{
 	{{- end -}}
 {{- end -}}
 `,
-	"structfield": `{{- header .Level .Name -}}
+	"spans": `{{- range . -}}
+	{{- if eq .Kind "plain" -}}
+		{{- escape .Text -}}
+	{{- else if eq .Kind "italic" -}}
+		{{- italic .Text -}}
+	{{- else if eq .Kind "code" -}}
+		{{- codeSpan .Text -}}
+	{{- else if or (eq .Kind "link") (eq .Kind "docLink") -}}
+		{{- link (escape .Text) .URL -}}
+	{{- end -}}
+{{- end -}}
+`,
+	"structfield": `{{- if .CustomTitle -}}
+	{{- header .Level .CustomTitle -}}
+{{- else -}}
+	{{- header .Level .Name -}}
+{{- end -}}
 {{- spacer -}}
 
+{{- if .Deprecated -}}
+	{{- template "deprecation" . -}}
+	{{- if len .Doc.Blocks -}}{{- spacer -}}{{- end -}}
+{{- end -}}
+
 {{- template "doc" .Doc -}}
+
+{{- if len .Examples -}}
+	{{- if or (len .Doc.Blocks) .Deprecated -}}{{- spacer -}}{{- end -}}
+
+	{{- range (iter .Examples) -}}
+		{{- template "example" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
 `,
-	"type": `{{- if .IsStructType -}}
-    {{- codeHref .Location | link (escape .Name) | printf "type %s" | rawHeader .Level -}}
+	"type": `{{- if or .IsStructType .IsInterfaceType -}}
+    {{- if .CustomTitle -}}
+        {{- header .Level .CustomTitle -}}
+    {{- else -}}
+        {{- codeHref .Location | link (escape .Name) | tr "type %s" | rawHeader .Level -}}
+    {{- end -}}
     {{- spacer -}}
 
-    {{- template "doc" .Doc -}}
-    {{- spacer -}}
+    {{- if .Deprecated -}}
+        {{- template "deprecation" . -}}
+        {{- spacer -}}
+    {{- end -}}
+
+    {{- if len .Doc.Blocks -}}
+        {{- template "doc" .Doc -}}
+        {{- spacer -}}
+    {{- end -}}
 
     {{- codeBlock "go" .Decl -}}
 
     {{- if .IsStructType -}}
-        {{- if len .Fields -}}
-            {{- spacer -}}
-            {{- range (iter .Fields) -}}
-                {{- if len .Entry.Doc.Blocks -}}
-                    {{- template "structfield" .Entry -}}
-                    {{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+        {{- range .FieldGroups -}}
+            {{- if .Name -}}
+                {{- spacer -}}
+                {{- header .Level .Title -}}
+            {{- end -}}
+
+            {{- range .Fields -}}
+                {{- if or (len .Doc.Blocks) .Deprecated (len .Examples) -}}
+                    {{- spacer -}}
+                    {{- template "structfield" . -}}
                 {{- end -}}
             {{- end -}}
         {{- end -}}
     {{- end -}}
 
+    {{- if .IsInterfaceType -}}
+        {{- range .InterfaceMethods -}}
+            {{- if or (len .Doc.Blocks) .Deprecated -}}
+                {{- spacer -}}
+                {{- template "interfacemethod" . -}}
+            {{- end -}}
+        {{- end -}}
+    {{- end -}}
+
     {{- if len .Examples -}}
         {{- spacer -}}
 
@@ -164,8 +394,15 @@ var templates = map[string]string{
 {{- end -}}
 
 `,
-	"value": `{{- template "doc" .Doc -}}
-{{- spacer -}}
+	"value": `{{- if .Deprecated -}}
+	{{- template "deprecation" . -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- if len .Doc.Blocks -}}
+	{{- template "doc" .Doc -}}
+	{{- spacer -}}
+{{- end -}}
 
 {{- codeBlock "go" .Decl -}}
 
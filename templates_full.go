@@ -0,0 +1,251 @@
+// Code generated by gentmpl.sh; DO NOT EDIT.
+
+package gomarkdoc
+
+var fullTemplates = map[string]string{
+	"index": `{{- if len .Consts -}}
+	{{- localHref (tr "Constants") | link (tr "Constants") | listEntry 0 -}}
+	{{- inlineSpacer -}}
+{{- end -}}
+
+{{- if len .Vars -}}
+	{{- localHref (tr "Variables") | link (tr "Variables") | listEntry 0 -}}
+	{{- inlineSpacer -}}
+{{- end -}}
+
+{{- if len .Funcs -}}
+	{{- localHref (tr "Functions") | link (tr "Functions") | listEntry 0 -}}
+	{{- inlineSpacer -}}
+
+	{{- range .Funcs -}}
+		{{- $entry := localHref .Title | link (escape .Signature) -}}
+		{{- if .Deprecated -}}{{- $entry = strikethrough $entry -}}{{- end -}}
+		{{- listEntry 1 $entry -}}
+		{{- inlineSpacer -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if len .Types -}}
+	{{- localHref (tr "Types") | link (tr "Types") | listEntry 0 -}}
+	{{- inlineSpacer -}}
+
+	{{- range .Types -}}
+		{{- $entry := localHref .Title | link .Title -}}
+		{{- if .Deprecated -}}{{- $entry = strikethrough $entry -}}{{- end -}}
+		{{- listEntry 1 $entry -}}
+		{{- inlineSpacer -}}
+
+		{{- range .Funcs -}}
+			{{- $entry := localHref .Title | link (escape .Signature) -}}
+			{{- if .Deprecated -}}{{- $entry = strikethrough $entry -}}{{- end -}}
+			{{- listEntry 2 $entry -}}
+			{{- inlineSpacer -}}
+		{{- end -}}
+
+		{{- range .Methods -}}
+			{{- $entry := localHref .Title | link (escape .Signature) -}}
+			{{- if .Deprecated -}}{{- $entry = strikethrough $entry -}}{{- end -}}
+			{{- listEntry 2 $entry -}}
+			{{- inlineSpacer -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
+`,
+	"modindex": `{{if .Header -}}
+	{{- .Header -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- header 1 (tr "Packages") -}}
+
+{{- range .Packages -}}
+	{{- $href := .Href -}}
+	{{- spacer -}}
+	{{- link (escape .ImportPath) $href | rawHeader 2 -}}
+
+	{{- if .Summary -}}
+		{{- spacer -}}
+		{{- escape .Summary -}}
+	{{- end -}}
+
+	{{- $first := true -}}
+	{{- range .Types -}}
+		{{- if $first -}}{{- spacer -}}{{- $first = false -}}{{- else -}}{{- inlineSpacer -}}{{- end -}}
+		{{- $entry := codeHref .Location | link (escape .Name) | tr "type %s" | localHref | printf "%s%s" $href | link .Title -}}
+		{{- if .CustomTitle -}}{{- $entry = localHref .CustomTitle | printf "%s%s" $href | link (escape .CustomTitle) -}}{{- end -}}
+		{{- if .Deprecated -}}{{- $entry = strikethrough $entry -}}{{- end -}}
+		{{- listEntry 0 $entry -}}
+	{{- end -}}
+{{- end -}}
+
+{{- spacer -}}
+
+{{- if .Footer -}}
+	{{- .Footer -}}
+	{{- spacer -}}
+{{- end -}}
+`,
+	"package": `{{- header .Level .Title -}}
+{{- spacer -}}
+
+{{- template "import" . -}}
+{{- spacer -}}
+
+{{- if .Deprecated -}}
+	{{- template "deprecation" . -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- if len .Doc.Blocks -}}
+	{{- template "doc" .Doc -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- range (iter .Examples) -}}
+	{{- template "example" .Entry -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- header (add .Level 1) (tr "Index") -}}
+{{- spacer -}}
+
+{{- template "index" . -}}
+
+{{- if len .Consts -}}
+	{{- spacer -}}
+
+	{{- header (add .Level 1) (tr "Constants") -}}
+	{{- spacer -}}
+
+	{{- range (iter .Consts) -}}
+		{{- template "value" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if len .Vars -}}
+	{{- spacer -}}
+
+	{{- header (add .Level 1) (tr "Variables") -}}
+	{{- spacer -}}
+
+	{{- range (iter .Vars) -}}
+		{{- template "value" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if len .Funcs -}}
+	{{- spacer -}}
+
+	{{- header (add .Level 1) (tr "Functions") -}}
+	{{- spacer -}}
+
+	{{- range (iter .Funcs) -}}
+		{{- template "func" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if len .Types -}}
+	{{- spacer -}}
+
+	{{- header (add .Level 1) (tr "Types") -}}
+	{{- spacer -}}
+
+	{{- range (iter .Types) -}}
+		{{- template "type" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+`,
+	"type": `{{- if .CustomTitle -}}
+	{{- header .Level .CustomTitle -}}
+{{- else -}}
+	{{- codeHref .Location | link (escape .Name) | tr "type %s" | rawHeader .Level -}}
+{{- end -}}
+{{- spacer -}}
+
+{{- if .Deprecated -}}
+	{{- template "deprecation" . -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- if len .Doc.Blocks -}}
+	{{- template "doc" .Doc -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- codeBlock "go" .Decl -}}
+
+{{- if .IsStructType -}}
+	{{- range .FieldGroups -}}
+		{{- if .Name -}}
+			{{- spacer -}}
+			{{- header .Level .Title -}}
+		{{- end -}}
+
+		{{- range .Fields -}}
+			{{- if or (len .Doc.Blocks) .Deprecated (len .Examples) -}}
+				{{- spacer -}}
+				{{- template "structfield" . -}}
+			{{- end -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if .IsInterfaceType -}}
+	{{- range .InterfaceMethods -}}
+		{{- if or (len .Doc.Blocks) .Deprecated -}}
+			{{- spacer -}}
+			{{- template "interfacemethod" . -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if len .Consts -}}
+	{{- spacer -}}
+
+	{{- range (iter .Consts) -}}
+		{{- template "value" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if len .Vars -}}
+	{{- spacer -}}
+
+	{{- range (iter .Vars) -}}
+		{{- template "value" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if len .Examples -}}
+	{{- spacer -}}
+
+	{{- range (iter .Examples) -}}
+		{{- template "example" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if len .Funcs -}}
+	{{- spacer -}}
+
+	{{- range (iter .Funcs) -}}
+		{{- template "func" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if len .Methods -}}
+	{{- spacer -}}
+
+	{{- range (iter .Methods) -}}
+		{{- template "func" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+`,
+}
@@ -0,0 +1,46 @@
+// Package deprecated contains symbols marked as deprecated.
+//
+// Deprecated: Use package function instead.
+package deprecated
+
+// Current is the current value.
+const Current = 2
+
+// Previous is the previous value.
+//
+// Deprecated: Use Current instead.
+const Previous = 1
+
+// Settings holds settings, some of which are scheduled for removal.
+type Settings struct {
+	// Name is the name.
+	Name string
+
+	// Alias is an alternative name.
+	//
+	// Deprecated: Use Name instead. Alias will be
+	// removed in the next major version.
+	Alias string
+}
+
+// Store stores values.
+type Store interface {
+	// Put stores a value.
+	Put(v string)
+
+	// Deprecated: Use Put instead.
+	Add(v string)
+}
+
+// Options are the old settings.
+//
+// Deprecated: Use Settings instead.
+type Options struct{}
+
+// Run runs.
+func Run() {}
+
+// Start starts.
+//
+// Deprecated: Use Run instead.
+func Start() {}
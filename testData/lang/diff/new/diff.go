@@ -0,0 +1,33 @@
+// Package diff is the newer version of a package compared by the API diff.
+package diff
+
+import "time"
+
+// Config is unchanged.
+type Config struct {
+	// Name is the name.
+	Name string `json:"name"`
+}
+
+// Settings holds the settings of the server.
+type Settings struct {
+	// Host is the host.
+	Host string `json:"host"`
+
+	// Timeout is the time to wait for a response.
+	Timeout time.Duration `json:"timeout"`
+
+	// Debug enables debug output.
+	Debug, Verbose bool
+
+	// Address is the address to listen on.
+	Address string `json:"address"`
+
+	time.Location
+}
+
+// Level is a level.
+type Level string
+
+// Added is added.
+type Added struct{}
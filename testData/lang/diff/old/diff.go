@@ -0,0 +1,29 @@
+// Package diff is the older version of a package compared by the API diff.
+package diff
+
+// Config is unchanged.
+type Config struct {
+	// Name is the name.
+	Name string `json:"name"`
+}
+
+// Settings holds settings.
+type Settings struct {
+	// Host is the host.
+	Host string `json:"host"`
+
+	// Port is the port.
+	Port int `json:"port"`
+
+	// Timeout is the timeout.
+	Timeout int
+
+	// Debug enables debug output.
+	Debug, Verbose bool `env:"DEBUG"`
+}
+
+// Level is a level.
+type Level int
+
+// Legacy is removed.
+type Legacy struct{}
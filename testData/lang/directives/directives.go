@@ -0,0 +1,60 @@
+// Package directives contains symbols customized with gomarkdoc directives.
+package directives
+
+// Visible is documented.
+const Visible = 1
+
+// Internal is only exported for tests.
+//
+//gomarkdoc:hide
+const Internal = 2
+
+// Server serves requests.
+//
+//gomarkdoc:title "The Server"
+//gomarkdoc:group core
+type Server struct {
+	// Name is the name of the server.
+	Name string
+
+	// Addr is the address to listen on.
+	//
+	//gomarkdoc:group Network
+	Addr string
+
+	// Timeout is the timeout for requests.
+	//
+	//gomarkdoc:group Network
+	//gomarkdoc:title "Request timeout"
+	Timeout int
+
+	// Debug enables debug output.
+	//
+	//gomarkdoc:hide
+	Debug bool
+
+	// Workers is the number of workers of the [Server].
+	//
+	//gomarkdoc:group Tuning
+	Workers int
+}
+
+// Serve serves the requests of the [directives.Server].
+//
+//gomarkdoc:group core
+func (s *Server) Serve() {}
+
+// Reset resets the server.
+//
+//gomarkdoc:hide
+func (s *Server) Reset() {}
+
+// Secret is hidden.
+//
+//gomarkdoc:hide
+type Secret struct{}
+
+// Run runs.
+//
+//gomarkdoc:title "Run it"
+func Run() {}
@@ -32,3 +32,25 @@ func ExampleGeneric_WithGenericReceiver() {
 	r := function.Generic[int]{}
 	r.WithGenericReceiver()
 }
+
+func ExamplePlugin_Init() {
+	var p function.Plugin
+	_, _ = p.Init("name")
+}
+
+func ExampleSettings() {
+	fmt.Println(function.Settings{Name: "default"}.Name)
+	// Output: default
+}
+
+func ExampleSettings_Name() {
+	s := function.Settings{Name: "default"}
+	fmt.Println(s.Name)
+	// Output: default
+}
+
+func ExampleSettings_Name_empty() {
+	var s function.Settings
+	fmt.Println(s.Name == "")
+	// Output: true
+}
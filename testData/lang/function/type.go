@@ -0,0 +1,37 @@
+package function
+
+import "fmt"
+
+// Mode is a type which is not a struct.
+type Mode int
+
+// Plugin is an interface type.
+type Plugin interface {
+	// Stringer is embedded.
+	fmt.Stringer
+
+	// Init initializes the plugin.
+	Init(name string, opts ...int) (bool, error)
+
+	// Close has no parameters.
+	Close() error
+}
+
+// Number is a constraint.
+type Number interface {
+	~int | ~float64
+}
+
+// Settings is a struct type with examples for its fields.
+type Settings struct {
+	// Name is the name of the settings.
+	//
+	// Example:
+	//
+	//	name: default
+	Name string
+
+	// Receiver shares its name with a type, whose examples don't belong to
+	// the field.
+	Receiver string
+}
@@ -0,0 +1,28 @@
+// Package lint has documentation problems. See [Config], [Config.Name],
+// [strings.Builder] and [Missing].
+package lint
+
+import "strings"
+
+// Config is documented.
+type Config struct {
+	// Name is documented.
+	Name string
+
+	Value int
+
+	strings.Builder
+}
+
+// A Server is documented with an article.
+type Server struct{}
+
+// Start starts the server, see [Config.Unknown].
+func (s *Server) Start() {}
+
+// Stops the server.
+func (s *Server) Stop() {}
+
+type Undocumented int
+
+func Run() {}
@@ -0,0 +1,23 @@
+// Package order contains types declared across files which reference each
+// other.
+package order
+
+// ServerConfig configures the server.
+type ServerConfig struct {
+	// TLS configures TLS.
+	TLS TLSConfig
+
+	// Port is the port to listen on.
+	Port int
+}
+
+// TLSConfig configures TLS.
+//
+//gomarkdoc:group security
+type TLSConfig struct {
+	// CertFile is the certificate.
+	CertFile string
+}
+
+// Start starts the server.
+func Start() {}
@@ -0,0 +1,30 @@
+package order
+
+// Config is the root configuration.
+//
+//gomarkdoc:group core
+type Config struct {
+	// Server configures the server.
+	Server ServerConfig
+
+	// Log configures logging.
+	Log LogConfig
+
+	// Zone is the time zone.
+	Zone string
+
+	// Age is the maximum age.
+	Age int
+}
+
+// LogConfig configures logging.
+type LogConfig struct {
+	// Level is the log level.
+	Level string
+}
+
+// Alpha is not referenced by any other type.
+type Alpha struct{}
+
+// Load loads the configuration.
+func Load() {}
@@ -0,0 +1,490 @@
+package gomarkdoc
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/parser"
+	"go/token"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudogu/gomarkdoc/lang"
+	"github.com/cloudogu/gomarkdoc/logger"
+)
+
+type (
+	// TemplateError describes a problem with a template, such as a reference to
+	// an unknown field or function.
+	TemplateError struct {
+		// Template holds the name of the template containing the problem.
+		Template string
+
+		// Line and Column locate the problem within the template. Column is 0
+		// if it is not known.
+		Line   int
+		Column int
+
+		// Path holds the expression that failed to evaluate (e.g. ".Doc.Foo"),
+		// if the problem occurred while executing the template.
+		Path string
+
+		// Message describes the problem.
+		Message string
+
+		err error
+	}
+
+	// TemplateErrors holds all problems found while validating templates.
+	TemplateErrors []*TemplateError
+)
+
+// Error provides a description of the problem including its location.
+func (e *TemplateError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Template)
+
+	if e.Line > 0 {
+		fmt.Fprintf(&b, ":%d", e.Line)
+	}
+
+	if e.Column > 0 {
+		fmt.Fprintf(&b, ":%d", e.Column)
+	}
+
+	if e.Path != "" {
+		fmt.Fprintf(&b, ": at <%s>", e.Path)
+	}
+
+	fmt.Fprintf(&b, ": %s", e.Message)
+
+	return b.String()
+}
+
+// Unwrap provides the original error reported by the template engine.
+func (e *TemplateError) Unwrap() error {
+	return e.err
+}
+
+// Error lists all contained problems, one per line.
+func (e TemplateErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// templateErrorRegex matches the location information that text/template adds
+// to parse and execution errors. Errors from templates rendered through the
+// include function are nested, in which case the last match is the most
+// specific one.
+var templateErrorRegex = regexp.MustCompile(
+	`template: ([^:\s]+):(\d+)(?::(\d+))?: (?:executing "[^"]*" at <(.*?)>: )?`,
+)
+
+// newTemplateError converts an error from the template engine into a
+// TemplateError. If the error does not contain location information, the
+// provided template name is used as its location.
+func newTemplateError(name string, err error) *TemplateError {
+	msg := err.Error()
+
+	matches := templateErrorRegex.FindAllStringSubmatchIndex(msg, -1)
+	if len(matches) == 0 {
+		return &TemplateError{Template: name, Message: msg, err: err}
+	}
+
+	m := matches[len(matches)-1]
+	group := func(i int) string {
+		if m[2*i] < 0 {
+			return ""
+		}
+
+		return msg[m[2*i]:m[2*i+1]]
+	}
+
+	line, _ := strconv.Atoi(group(2))
+	col, _ := strconv.Atoi(group(3))
+
+	return &TemplateError{
+		Template: group(1),
+		Line:     line,
+		Column:   col,
+		Path:     group(4),
+		Message:  strings.TrimPrefix(msg[m[1]:], "error calling "),
+		err:      err,
+	}
+}
+
+// Validate executes every template known to the renderer against a synthetic
+// package which exercises all of the documentation constructs. This detects
+// references to unknown fields, invalid function arguments and similar
+// problems before any real documentation is rendered. Problems with parsing
+// templates, such as unknown functions, are already reported by NewRenderer.
+//
+// If problems are found, a TemplateErrors value holding all of them is
+// returned.
+func (out *Renderer) Validate() error {
+	data, err := sampleData()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	seen := make(map[string]bool)
+	var errs TemplateErrors
+	for _, name := range names {
+		if out.tmpl.Lookup(name) == nil {
+			continue
+		}
+
+		for _, d := range data[name] {
+			if err := out.tmpl.ExecuteTemplate(io.Discard, name, d); err != nil {
+				tmplErr := newTemplateError(name, err)
+
+				// A broken template is usually reached from several others.
+				// Only report each problem once.
+				if key := tmplErr.Error(); !seen[key] {
+					seen[key] = true
+					errs = append(errs, tmplErr)
+				}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// AsTemplateErrors extracts the template problems contained in an error
+// returned by NewRenderer or Renderer.Validate. The second return value is
+// false if the error is not related to templates.
+func AsTemplateErrors(err error) (TemplateErrors, bool) {
+	var errs TemplateErrors
+	if errors.As(err, &errs) {
+		return errs, true
+	}
+
+	var tmplErr *TemplateError
+	if errors.As(err, &tmplErr) {
+		return TemplateErrors{tmplErr}, true
+	}
+
+	return nil, false
+}
+
+// sampleSource holds the code of the synthetic package used to validate
+// templates. It should contain an instance of every construct the templates
+// may render.
+const sampleSource = `// Package sample is a synthetic package used to validate templates.
+//
+// # Heading
+//
+// A paragraph referencing [Config], [Config.Validate] and [strings.Builder] as
+// well as an [external link], https://example.com and ` + "`inline code`" + `.
+//
+// An unordered list:
+//   - First
+//   - Second
+//
+// An ordered list:
+//  1. First
+//
+//  2. Second
+//
+// A code block:
+//
+//	sample.Helper()
+//
+// Note: A callout.
+//
+// [external link]: https://pkg.go.dev
+package sample
+
+import "fmt"
+
+// Version is a documented constant.
+const Version = "1.0"
+
+// Enabled is a documented variable.
+var Enabled = true
+
+// Config is a documented struct type.
+type Config struct {
+	// Name is a documented field.
+	Name string ` + "`json:\"name\"`" + `
+
+	// Count and Limit share a declaration.
+	Count, Limit int
+
+	undocumented bool
+
+	// Legacy is a deprecated field.
+	//
+	// Deprecated: Use Name instead.
+	Legacy string
+
+	// Timeout is a grouped field with a custom title.
+	//
+	// Example:
+	//
+	//	cfg.Timeout = 30
+	//
+	//gomarkdoc:group Network
+	//gomarkdoc:title "Request timeout"
+	Timeout int
+}
+
+// NewConfig is a constructor of Config.
+func NewConfig(name string) *Config {
+	return &Config{Name: name}
+}
+
+// Validate is a method with a pointer receiver.
+func (c *Config) Validate() error {
+	return nil
+}
+
+// Mode is a documented non-struct type.
+type Mode int
+
+// Modes are typed constants.
+const (
+	ModeA Mode = iota
+	ModeB
+)
+
+// String is a method with a value receiver.
+func (m Mode) String() string {
+	return ""
+}
+
+// Plugin is a documented interface type.
+//
+//gomarkdoc:title "Plugins"
+type Plugin interface {
+	// Embedded interfaces can be documented.
+	fmt.Stringer
+
+	// Init is a documented method.
+	Init(cfg *Config) error
+
+	// Deprecated: Use Init instead.
+	Setup()
+
+	undocumented()
+}
+
+// Number is a documented constraint interface.
+type Number interface {
+	// Type sets can be documented.
+	~int | ~float64
+}
+
+// Helper is a documented function.
+//
+//gomarkdoc:title "Helper function"
+func Helper() {}
+
+// OldHelper is a deprecated function.
+//
+// Deprecated: Use Helper instead.
+func OldHelper() {}
+
+// Deprecated: Legacy is only kept for compatibility.
+var Legacy = false
+`
+
+// sampleTestSource holds the examples of the synthetic package.
+const sampleTestSource = `package sample
+
+import "fmt"
+
+// This is the package example.
+func Example() {
+	fmt.Println("sample")
+	// Output: sample
+}
+
+func Example_named() {}
+
+func ExampleConfig() {}
+
+func ExampleConfig_Validate() {}
+
+func ExampleConfig_Name() {}
+
+func ExamplePlugin_Init() {}
+
+func ExampleHelper() {
+	// Output:
+}
+`
+
+// sampleAPIDiff holds the changes of a synthetic API change report, covering
+// every kind of change.
+var sampleAPIDiff = []*lang.PackageDiff{
+	{ImportPath: "example.com/added", Kind: lang.Added},
+	{ImportPath: "example.com/removed", Kind: lang.Removed},
+	{
+		ImportPath: "example.com/sample",
+		Kind:       lang.Changed,
+		DocChanged: true,
+		Types: []*lang.TypeDiff{
+			{Name: "Added", Kind: lang.Added},
+			{Name: "Removed", Kind: lang.Removed},
+			{
+				Name:        "Level",
+				Kind:        lang.Changed,
+				DocChanged:  true,
+				DeclChanged: true,
+				OldDecl:     "type Level int",
+				NewDecl:     "type Level string",
+			},
+			{
+				Name: "Config",
+				Kind: lang.Changed,
+				Fields: []*lang.FieldDiff{
+					{Name: "Name", Kind: lang.Added, NewType: "string", NewTag: `json:"name"`},
+					{Name: "Addr", Kind: lang.Added, NewType: "string"},
+					{Name: "Port", Kind: lang.Removed, OldType: "int"},
+					{Name: "Timeout", Kind: lang.Changed, OldType: "int", NewType: "time.Duration", OldTag: `json:"t"`, NewTag: `json:"timeout"`},
+					{Name: "Debug", Kind: lang.Changed, OldType: "bool", NewType: "bool", NewTag: `json:"debug"`, DocChanged: true},
+					{Name: "Verbose", Kind: lang.Changed, OldType: "bool", NewType: "bool", OldTag: `json:"verbose"`},
+				},
+			},
+		},
+	},
+}
+
+// sampleData creates the data used to validate each of the templates, keyed
+// by template name.
+func sampleData() (map[string][]any, error) {
+	fs := token.NewFileSet()
+
+	src, err := parser.ParseFile(fs, "sample.go", sampleSource, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: invalid sample package: %w", err)
+	}
+
+	test, err := parser.ParseFile(fs, "sample_test.go", sampleTestSource, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: invalid sample package: %w", err)
+	}
+
+	// The AST is preserved so that the directives of the declarations are
+	// still available to the documentation constructs.
+	docPkg, err := doc.NewFromFiles(fs, []*ast.File{src}, "example.com/sample", doc.AllDecls|doc.PreserveAST)
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: invalid sample package: %w", err)
+	}
+
+	cfg := &lang.Config{
+		FileSet: fs,
+		Level:   1,
+		Log:     logger.New(logger.ErrorLevel),
+		Repo: &lang.Repo{
+			Remote:        "https://example.com/sample",
+			DefaultBranch: "main",
+			PathFromRoot:  "/",
+		},
+	}
+
+	pkg := lang.NewPackage(cfg, docPkg, doc.Examples(test))
+
+	data := map[string][]any{
+		"file": {lang.NewFile("Header", "Footer", []*lang.Package{pkg}), lang.NewFile("", "", nil)},
+		"modindex": {
+			lang.NewModIndex("Header", "Footer", []*lang.ModIndexEntry{{Package: pkg, Href: "sample/README.md"}}),
+			lang.NewModIndex("", "", nil),
+		},
+		"apidiff": {
+			lang.NewAPIDiff("Header", "Footer", "v1.0.0", "v1.1.0", sampleAPIDiff),
+			lang.NewAPIDiff("", "", "v1.0.0", "v1.1.0", nil),
+		},
+		"package": {pkg},
+		"index":   {pkg},
+		"import":  {pkg},
+		"doc":     {pkg.Doc()},
+	}
+
+	addDeprecated := func(sym interface{ Deprecated() bool }) {
+		if sym.Deprecated() {
+			data["deprecation"] = append(data["deprecation"], sym)
+		}
+	}
+
+	addDoc := func(d *lang.Doc) {
+		for _, b := range d.Blocks() {
+			switch b.Kind() {
+			case lang.ListBlock:
+				data["list"] = append(data["list"], b.List())
+			case lang.ParagraphBlock, lang.CalloutBlock:
+				data["spans"] = append(data["spans"], b.Spans())
+			}
+		}
+	}
+	addDoc(pkg.Doc())
+
+	addExamples := func(examples []*lang.Example) {
+		for _, ex := range examples {
+			data["example"] = append(data["example"], ex)
+		}
+	}
+	addExamples(pkg.Examples())
+
+	addFuncs := func(funcs []*lang.Func) {
+		for _, fn := range funcs {
+			data["func"] = append(data["func"], fn)
+			addDeprecated(fn)
+			addExamples(fn.Examples())
+		}
+	}
+	addFuncs(pkg.Funcs())
+
+	addValues := func(values []*lang.Value) {
+		for _, v := range values {
+			data["value"] = append(data["value"], v)
+			addDeprecated(v)
+		}
+	}
+	addValues(pkg.Consts())
+	addValues(pkg.Vars())
+
+	for _, typ := range pkg.Types() {
+		data["type"] = append(data["type"], typ)
+		addExamples(typ.Examples())
+		addFuncs(typ.Funcs())
+		addFuncs(typ.Methods())
+		addValues(typ.Consts())
+		addValues(typ.Vars())
+
+		for _, g := range typ.FieldGroups() {
+			for _, f := range g.Fields() {
+				data["structfield"] = append(data["structfield"], f)
+				addExamples(f.Examples())
+			}
+		}
+
+		for _, m := range typ.InterfaceMethods() {
+			data["interfacemethod"] = append(data["interfacemethod"], m)
+			addExamples(m.Examples())
+		}
+	}
+
+	return data, nil
+}
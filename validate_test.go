@@ -0,0 +1,59 @@
+package gomarkdoc_test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/cloudogu/gomarkdoc"
+)
+
+func TestRenderer_Validate(t *testing.T) {
+	is := is.New(t)
+
+	r, err := gomarkdoc.NewRenderer()
+	is.NoErr(err)
+	is.NoErr(r.Validate()) // default templates are valid
+
+	r, err = gomarkdoc.NewRenderer(gomarkdoc.WithTemplateOverride("structfield", "{{ .Name }}\n{{ .Unknown.Name }}"))
+	is.NoErr(err)
+
+	errs, ok := gomarkdoc.AsTemplateErrors(r.Validate())
+	is.True(ok)
+	is.Equal(len(errs), 1) // reported once although reached from several templates
+	is.Equal(errs[0].Template, "structfield")
+	is.Equal(errs[0].Line, 2)
+	is.Equal(errs[0].Column, 11)
+	is.Equal(errs[0].Path, ".Unknown.Name")
+	is.Equal(errs[0].Error(), "structfield:2:11: at <.Unknown.Name>: can't evaluate field Unknown in type *lang.Field")
+}
+
+func TestRenderer_Validate_include(t *testing.T) {
+	is := is.New(t)
+
+	r, err := gomarkdoc.NewRenderer(
+		gomarkdoc.WithAdditionalTemplate("partial", "\n{{ .Missing }}"),
+		gomarkdoc.WithTemplateOverride("package", `{{ include "partial" . }}`),
+	)
+	is.NoErr(err)
+
+	errs, ok := gomarkdoc.AsTemplateErrors(r.Validate())
+	is.True(ok)
+	is.True(len(errs) > 0)
+	is.Equal(errs[0].Template, "partial") // the innermost template is reported
+	is.Equal(errs[0].Line, 2)
+	is.Equal(errs[0].Path, ".Missing")
+}
+
+func TestNewRenderer_parseError(t *testing.T) {
+	is := is.New(t)
+
+	_, err := gomarkdoc.NewRenderer(gomarkdoc.WithTemplateOverride("type", "{{ .Name }}\n{{ unknownFunc . }}"))
+
+	errs, ok := gomarkdoc.AsTemplateErrors(err)
+	is.True(ok)
+	is.Equal(len(errs), 1)
+	is.Equal(errs[0].Template, "type")
+	is.Equal(errs[0].Line, 2)
+	is.Equal(errs[0].Message, `function "unknownFunc" not defined`)
+}